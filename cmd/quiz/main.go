@@ -1,7 +1,11 @@
 package main
 
-import "github.com/mbilarusdev/quiz/internal/app"
+import (
+	"os"
+
+	"github.com/mbilarusdev/quiz/internal/cli"
+)
 
 func main() {
-	app.NewQuizApp().Start()
+	os.Exit(cli.Run(os.Args[1:]))
 }
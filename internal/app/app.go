@@ -1,85 +1,312 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
-	"time"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/auth"
 	"github.com/mbilarusdev/quiz/internal/common"
 	"github.com/mbilarusdev/quiz/internal/handler"
+	"github.com/mbilarusdev/quiz/internal/metrics"
+	"github.com/mbilarusdev/quiz/internal/middleware"
+	"github.com/mbilarusdev/quiz/internal/migration"
 	"github.com/mbilarusdev/quiz/internal/repository"
 	"github.com/mbilarusdev/quiz/internal/service"
-	"gorm.io/driver/postgres"
+	"github.com/mbilarusdev/quiz/migrations"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type App interface {
-	Start()
+	// Start initializes dependencies and serves requests in background.
+	Start() error
+	// Stop drains in-flight requests until ctx is done and releases resources.
+	Stop(ctx context.Context) error
 }
 
-type QuizApp struct{}
+type QuizApp struct {
+	Config *common.QuizConfig
 
-func NewQuizApp() *QuizApp {
-	return new(QuizApp)
+	server        *http.Server
+	listener      net.Listener
+	db            *gorm.DB
+	cancelWorkers context.CancelFunc
+	workers       sync.WaitGroup
+	serveErr      chan error
+	stopOnce      sync.Once
+	stopErr       error
 }
 
-func (app *QuizApp) Start() {
-	// Logger
-	fmt.Println("Init logger...")
-	common.InitLogger()
-	defer common.L.Sync()
+func NewQuizApp(config *common.QuizConfig) *QuizApp {
+	app := new(QuizApp)
+	app.Config = config
+	app.serveErr = make(chan error, 1)
+	return app
+}
+
+// Run starts app and stops it on SIGINT/SIGTERM or when server fails.
+func (app *QuizApp) Run() error {
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	startErr := app.Start()
+	if startErr == nil {
+		select {
+		case <-signalCtx.Done():
+			fmt.Println("Shutting down...")
+		case startErr = <-app.serveErr:
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.Config.ShutdownTimeout)
+	defer cancel()
+	return errors.Join(startErr, app.Stop(ctx))
+}
 
-	// Config
-	fmt.Println("Parsing config...")
-	common.Conf = common.NewQuizConfig()
-	dsn := common.Conf.PostgresDsn
+// Addr returns address server listens on, it is known after Start.
+func (app *QuizApp) Addr() net.Addr {
+	if app.listener == nil {
+		return nil
+	}
+	return app.listener.Addr()
+}
+
+func (app *QuizApp) Start() error {
+	// Logger and config
+	fmt.Println("Init logger and config...")
+	Init(app.Config)
 
 	// Postgres
-	time.Sleep(time.Second * 5)
 	fmt.Println("Connect postgres...")
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := OpenPostgres()
+	if err != nil {
+		return err
+	}
+	app.db = db
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := db.Use(repository.NewQueryTimeout(common.Conf.DbQueryTimeout)); err != nil {
+		return fmt.Errorf("can't bound postgres queries: %w", err)
+	}
+	if err := db.Use(metrics.NewGormPlugin()); err != nil {
+		return fmt.Errorf("can't instrument postgres: %w", err)
+	}
+	if err := metrics.RegisterDB(sqlDB, "postgres"); err != nil {
+		return fmt.Errorf("can't register postgres metrics: %w", err)
+	}
+	migrator, err := migration.NewMigrator(sqlDB, migrations.FS, common.Conf.MigrationsTable)
+	if err != nil {
+		return fmt.Errorf("can't load migrations: %w", err)
+	}
+	if common.Conf.MigrateOnStart {
+		fmt.Println("Apply migrations...")
+		if err := migrator.Run(context.Background(), migration.CommandUp, os.Stdout); err != nil {
+			return fmt.Errorf("can't apply migrations: %w", err)
+		}
+	}
+
+	// Auth
+	fmt.Println("Init token verifier...")
+	var publicKeyPEM []byte
+	if common.Conf.JwtPublicKeyFile != "" {
+		publicKeyPEM, err = os.ReadFile(common.Conf.JwtPublicKeyFile)
+		if err != nil {
+			return fmt.Errorf("can't read JWT public key: %w", err)
+		}
+	}
+	verifier, err := auth.NewVerifier(common.Conf.JwtAlgorithm, common.Conf.JwtSecret, publicKeyPEM)
 	if err != nil {
-		panic(fmt.Sprintf("Can't open postgres connection: %v\n", err))
+		return fmt.Errorf("can't init token verifier: %w", err)
+	}
+	fmt.Println("Init token issuer...")
+	var privateKeyPEM []byte
+	if common.Conf.JwtPrivateKeyFile != "" {
+		privateKeyPEM, err = os.ReadFile(common.Conf.JwtPrivateKeyFile)
+		if err != nil {
+			return fmt.Errorf("can't read JWT private key: %w", err)
+		}
+	}
+	issuer, err := auth.NewIssuer(common.Conf.JwtAlgorithm, common.Conf.JwtSecret, privateKeyPEM, common.Conf.JwtTTL)
+	if err != nil {
+		return fmt.Errorf("can't init token issuer: %w", err)
 	}
 
 	// Repositories
 	questionRepo := repository.NewQuestionRepository(db)
 	answerRepo := repository.NewAnswerRepository(db)
+	quizRepo := repository.NewQuizRepository(db)
+	attemptRepo := repository.NewAttemptRepository(db)
+	leaderboardRepo := repository.NewLeaderboardRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	userRepo := repository.NewUserRepository(db)
 
 	// Services
-	questionSrv := service.NewQuestionService(questionRepo, answerRepo)
+	questionSrv := service.NewQuestionService(questionRepo, answerRepo, db)
 	answerSrv := service.NewAnswerService(answerRepo, questionRepo, db)
+	quizSrv := service.NewQuizService(quizRepo, questionRepo, db)
+	attemptSrv := service.NewAttemptService(attemptRepo, quizRepo, questionRepo, db)
+	leaderboardSrv := service.NewLeaderboardService(leaderboardRepo, quizRepo)
+	apiKeySrv := service.NewAPIKeyService(apiKeyRepo)
+	healthSrv := service.NewHealthService(db, migrator)
+	logLevelSrv := service.NewLogLevelService(common.Level)
+	userSrv := service.NewUserService(userRepo, issuer)
+
+	// Background workers
+	workersCtx, cancelWorkers := context.WithCancel(context.Background())
+	app.cancelWorkers = cancelWorkers
+	attemptSweeper := service.NewAttemptSweeper(attemptSrv, common.Conf.AttemptSweepInterval)
+	app.workers.Go(func() {
+		attemptSweeper.Run(workersCtx)
+	})
 
 	// Handlers
 	questionHandler := handler.NewQuestionHandler(questionSrv)
 	answerHandler := handler.NewAnswerHandler(answerSrv)
+	quizHandler := handler.NewQuizHandler(quizSrv)
+	attemptHandler := handler.NewAttemptHandler(attemptSrv)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardSrv)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeySrv)
+	userHandler := handler.NewUserHandler(userSrv)
+	healthHandler := handler.NewHealthHandler(healthSrv)
+	logLevelHandler := handler.NewLogLevelHandler(logLevelSrv)
 
 	router := mux.NewRouter()
 
+	// Public
+	router.HandleFunc("/healthz", healthHandler.Live).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", healthHandler.Ready).Methods(http.MethodGet)
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	router.HandleFunc("/auth/register", userHandler.Register).Methods(http.MethodPost)
+	router.HandleFunc("/auth/login", userHandler.Login).Methods(http.MethodPost)
+
+	api := router.PathPrefix("/").Subrouter()
+	api.Use(auth.Middleware(verifier, apiKeySrv))
+
 	// Questions
-	router.HandleFunc("/questions", questionHandler.FindAll).Methods(http.MethodGet)
-	router.HandleFunc("/questions", questionHandler.Create).Methods(http.MethodPost)
-	router.HandleFunc("/questions/{id}", questionHandler.FindOneDetailed).Methods(http.MethodGet)
-	router.HandleFunc("/questions/{id}", questionHandler.Delete).Methods(http.MethodDelete)
+	api.HandleFunc("/questions", questionHandler.FindAll).Methods(http.MethodGet)
+	api.HandleFunc("/questions", questionHandler.Create).Methods(http.MethodPost)
+	api.HandleFunc("/questions/{id}", questionHandler.FindOneDetailed).Methods(http.MethodGet)
+	api.HandleFunc("/questions/{id}", questionHandler.Delete).Methods(http.MethodDelete)
+	api.HandleFunc("/questions/{id}", questionHandler.Update).Methods(http.MethodPut)
+	api.HandleFunc("/questions/{id}", questionHandler.Patch).Methods(http.MethodPatch)
+	api.HandleFunc("/questions/{id}/submissions", questionHandler.Submit).Methods(http.MethodPost)
+	api.HandleFunc("/questions/{id}/restore", questionHandler.Restore).Methods(http.MethodPost)
 
 	// Answers
-	router.HandleFunc("/questions/{id}/answers", answerHandler.AddAnswer).Methods(http.MethodPost)
-	router.HandleFunc("/answers/{id}", answerHandler.FindOne).Methods(http.MethodGet)
-	router.HandleFunc("/answers/{id}", answerHandler.Delete).Methods(http.MethodDelete)
+	api.HandleFunc("/questions/{id}/answers", answerHandler.FindByQuestion).Methods(http.MethodGet)
+	api.HandleFunc("/questions/{id}/answers", answerHandler.AddAnswer).Methods(http.MethodPost)
+	api.HandleFunc("/users/{user_id}/answers", answerHandler.FindByUser).Methods(http.MethodGet)
+	api.HandleFunc("/answers/{id}", answerHandler.FindOne).Methods(http.MethodGet)
+	api.HandleFunc("/answers/{id}", answerHandler.Delete).Methods(http.MethodDelete)
+	api.HandleFunc("/answers/{id}", answerHandler.Update).Methods(http.MethodPut)
+	api.HandleFunc("/answers/{id}", answerHandler.Patch).Methods(http.MethodPatch)
+	api.HandleFunc("/answers/{id}/restore", answerHandler.Restore).Methods(http.MethodPost)
+
+	// Trash
+	api.HandleFunc("/trash/questions", questionHandler.FindTrash).Methods(http.MethodGet)
+	api.HandleFunc("/trash/answers", answerHandler.FindTrash).Methods(http.MethodGet)
+
+	// Quizzes
+	api.HandleFunc("/quizzes", quizHandler.FindAll).Methods(http.MethodGet)
+	api.HandleFunc("/quizzes", quizHandler.Create).Methods(http.MethodPost)
+	api.HandleFunc("/quizzes/{id}", quizHandler.FindOne).Methods(http.MethodGet)
+	api.HandleFunc("/quizzes/{id}", quizHandler.Update).Methods(http.MethodPut)
+	api.HandleFunc("/quizzes/{id}", quizHandler.Delete).Methods(http.MethodDelete)
+	api.HandleFunc("/quizzes/{id}/questions", quizHandler.FindQuestions).Methods(http.MethodGet)
+	api.HandleFunc("/quizzes/{id}/questions", quizHandler.SetQuestions).Methods(http.MethodPut)
+
+	// Attempts
+	api.HandleFunc("/quizzes/{id}/attempts", attemptHandler.Start).Methods(http.MethodPost)
+	api.HandleFunc("/attempts/{id}", attemptHandler.FindOne).Methods(http.MethodGet)
+	api.HandleFunc("/attempts/{id}/next", attemptHandler.Next).Methods(http.MethodPost)
+	api.HandleFunc("/attempts/{id}/responses", attemptHandler.Respond).Methods(http.MethodPost)
+	api.HandleFunc("/attempts/{id}/finish", attemptHandler.Finish).Methods(http.MethodPost)
+	api.HandleFunc("/attempts/{id}/abandon", attemptHandler.Abandon).Methods(http.MethodPost)
+
+	// Leaderboards
+	api.HandleFunc("/leaderboards", leaderboardHandler.Find).Methods(http.MethodGet)
+
+	// API keys
+	api.HandleFunc("/admin/api-keys", apiKeyHandler.FindAll).Methods(http.MethodGet)
+	api.HandleFunc("/admin/api-keys", apiKeyHandler.Issue).Methods(http.MethodPost)
+	api.HandleFunc("/admin/api-keys/{id}", apiKeyHandler.Revoke).Methods(http.MethodDelete)
+
+	// Logging
+	api.HandleFunc("/admin/log-level", logLevelHandler.Find).Methods(http.MethodGet)
+	api.HandleFunc("/admin/log-level", logLevelHandler.Update).Methods(http.MethodPut)
+
+	// Users
+	api.HandleFunc("/users/me", userHandler.FindMe).Methods(http.MethodGet)
+	api.HandleFunc("/users/me", userHandler.UpdateMe).Methods(http.MethodPut)
+	api.HandleFunc("/users/me/password", userHandler.ChangePassword).Methods(http.MethodPut)
 
 	fmt.Println("Starting server...")
 
-	server := &http.Server{
-		Addr:           common.Conf.Addr,
-		Handler:        router,
-		ReadTimeout:    5 * time.Second,
-		WriteTimeout:   10 * time.Second,
-		MaxHeaderBytes: 1 << 20,
-	}
+	// Outermost middleware runs first
+	var httpHandler http.Handler = router
+	httpHandler = middleware.Metrics(router)(httpHandler)
+	httpHandler = middleware.AccessLog(httpHandler)
+	httpHandler = middleware.RequestID(httpHandler)
 
-	if err := server.ListenAndServe(); err != nil {
-		panic(fmt.Sprintf("Failed to start server: %v\n", err))
+	listener, err := net.Listen("tcp", common.Conf.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen %v: %w", common.Conf.Addr, err)
 	}
+	app.listener = listener
+	app.server = &http.Server{
+		Addr:           common.Conf.Addr,
+		Handler:        httpHandler,
+		ReadTimeout:    common.Conf.HttpReadTimeout,
+		WriteTimeout:   common.Conf.HttpWriteTimeout,
+		MaxHeaderBytes: common.Conf.HttpMaxHeaderBytes,
+	}
+	go func() {
+		if err := app.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			app.serveErr <- fmt.Errorf("failed to serve: %w", err)
+		}
+	}()
+	common.L.Info("Server started", zap.String("addr", listener.Addr().String()))
+	return nil
+}
 
+// Stop is safe to call several times and after failed Start, resources which
+// were not initialized are skipped.
+func (app *QuizApp) Stop(ctx context.Context) error {
+	app.stopOnce.Do(func() {
+		var errs []error
+		if app.server != nil {
+			if err := app.server.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to drain server: %w", err))
+				app.server.Close()
+			}
+		}
+		if app.cancelWorkers != nil {
+			app.cancelWorkers()
+		}
+		app.workers.Wait()
+		if app.db != nil {
+			if sqlDB, err := app.db.DB(); err != nil {
+				errs = append(errs, err)
+			} else if err := sqlDB.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close postgres: %w", err))
+			}
+		}
+		if common.L != nil {
+			common.L.Info("Server stopped")
+			// Sync of stdout/stderr fails on some platforms, it is not an error
+			_ = common.L.Sync()
+		}
+		app.stopErr = errors.Join(errs...)
+	})
+	return app.stopErr
 }
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Init makes config global and initializes logger, it is shared by server
+// and CLI commands.
+func Init(config *common.QuizConfig) {
+	common.Conf = config
+	common.InitLogger()
+}
+
+// OpenPostgres connects to configured postgres, Init must be called before.
+func OpenPostgres() (*gorm.DB, error) {
+	db, err := connectPostgres(
+		common.Conf.PostgresDsn,
+		common.Conf.DbConnectAttempts,
+		common.Conf.DbConnectBackoff,
+		common.Conf.DbConnectMaxBackoff,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't open postgres connection: %w", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(common.Conf.DbMaxOpenConns)
+	sqlDB.SetMaxIdleConns(common.Conf.DbMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(common.Conf.DbConnMaxLifetime)
+	return db, nil
+}
+
+// connectPostgres opens connection, retrying with exponential backoff while
+// postgres is starting up. Backoff is doubled after every failed attempt up to
+// maxBackoff.
+func connectPostgres(
+	dsn string,
+	attempts int,
+	backoff time.Duration,
+	maxBackoff time.Duration,
+) (*gorm.DB, error) {
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		if err == nil {
+			return db, nil
+		}
+		lastErr = err
+		if attempt == attempts {
+			break
+		}
+		common.L.Warn("Postgres is not available, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("attempts", attempts),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxBackoff)
+	}
+	return nil, fmt.Errorf("postgres is not available after %v attempts: %w", attempts, lastErr)
+}
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+)
+
+type contextKey int
+
+const actorKey contextKey = iota
+
+// WithActor returns copy of ctx carrying authenticated actor.
+func WithActor(ctx context.Context, actor *model.Actor) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFrom returns authenticated actor put into ctx by Middleware.
+func ActorFrom(ctx context.Context) (*model.Actor, bool) {
+	actor, ok := ctx.Value(actorKey).(*model.Actor)
+	return actor, ok
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mbilarusdev/quiz/internal/model"
+)
+
+type Issuer struct {
+	Method jwt.SigningMethod
+	Key    any
+	TTL    time.Duration
+}
+
+// NewIssuer creates issuer of tokens accepted by Verifier with the same
+// algorithm, RS256 tokens are signed with PEM encoded private key.
+func NewIssuer(algorithm string, secret string, privateKeyPEM []byte, ttl time.Duration) (*Issuer, error) {
+	issuer := new(Issuer)
+	issuer.TTL = ttl
+	switch algorithm {
+	case HS256:
+		if secret == "" {
+			return nil, fmt.Errorf("secret is required for %v", algorithm)
+		}
+		issuer.Method = jwt.SigningMethodHS256
+		issuer.Key = []byte(secret)
+	case RS256:
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key for %v: %w", algorithm, err)
+		}
+		issuer.Method = jwt.SigningMethodRS256
+		issuer.Key = key
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	return issuer, nil
+}
+
+// Issue signs token of actor, which expires after TTL.
+func (issuer *Issuer) Issue(actor *model.Actor) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(issuer.TTL)
+	claims := Claims{
+		Role: actor.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   actor.UserID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(issuer.Method, claims).SignedString(issuer.Key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
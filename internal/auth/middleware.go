@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+	"go.uber.org/zap"
+)
+
+const (
+	SchemeBearer = "Bearer"
+	SchemeAPIKey = "ApiKey"
+)
+
+// KeyAuthenticator resolves actor of API key.
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*model.Actor, error)
+}
+
+// Middleware authenticates requests with bearer token or API key and puts
+// actor into request context.
+func Middleware(verifier *Verifier, keys KeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op := "auth.Middleware"
+			scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			credentials = strings.TrimSpace(credentials)
+			if credentials == "" {
+				scheme = ""
+			}
+
+			var actor *model.Actor
+			var err error
+			switch {
+			case strings.EqualFold(scheme, SchemeBearer):
+				actor, err = verifier.Verify(credentials)
+				if err != nil {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="quiz", error="invalid_token"`)
+					sendUnauthorized(w, r, op, "Bearer token is invalid", err)
+					return
+				}
+			case strings.EqualFold(scheme, SchemeAPIKey):
+				actor, err = keys.Authenticate(r.Context(), credentials)
+				if err != nil {
+					if _, ok := err.(*service_errors.NotFoundError); ok {
+						sendUnauthorized(w, r, op, "API key is invalid", err)
+					} else if stateErr, ok := err.(*service_errors.StateError); ok {
+						sendUnauthorized(w, r, op, fmt.Sprintf("API key is %v", stateErr.State), err)
+					} else {
+						util.SendError(
+							model.SendError{
+								W:           w,
+								R:           r,
+								HandlerName: op,
+								ErrorMsg:    "Failed to authenticate API key, some error occured",
+								Error:       err,
+								StatusCode:  http.StatusInternalServerError,
+							},
+						)
+					}
+					return
+				}
+			default:
+				w.Header().Set("WWW-Authenticate", `Bearer realm="quiz"`)
+				w.Header().Add("WWW-Authenticate", `ApiKey realm="quiz"`)
+				sendUnauthorized(
+					w,
+					r,
+					op,
+					"Header 'Authorization' with bearer token or API key is required",
+					fmt.Errorf("missing credentials"),
+				)
+				return
+			}
+			ctx := WithActor(r.Context(), actor)
+			ctx = common.WithLogger(ctx, common.Log(ctx).With(zap.String("user_id", actor.UserID.String())))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func sendUnauthorized(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	errorMsg string,
+	err error,
+) {
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg:    errorMsg,
+			Error:       err,
+			StatusCode:  http.StatusUnauthorized,
+		},
+	)
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/model"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+type Verifier struct {
+	Algorithm string
+	Key       any
+}
+
+// NewVerifier creates verifier of tokens signed with HS256 shared secret or
+// with RS256 private key matching PEM encoded public key.
+func NewVerifier(algorithm string, secret string, publicKeyPEM []byte) (*Verifier, error) {
+	verifier := new(Verifier)
+	verifier.Algorithm = algorithm
+	switch algorithm {
+	case HS256:
+		if secret == "" {
+			return nil, fmt.Errorf("secret is required for %v", algorithm)
+		}
+		verifier.Key = []byte(secret)
+	case RS256:
+		key, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key for %v: %w", algorithm, err)
+		}
+		verifier.Key = key
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	return verifier, nil
+}
+
+// Claims are claims of token, role claim defaults to player.
+type Claims struct {
+	Role string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Verify checks signature and expiration of token and returns actor
+// identified by its subject and role claims.
+func (verifier *Verifier) Verify(tokenString string) (*model.Actor, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		func(_ *jwt.Token) (any, error) {
+			return verifier.Key, nil
+		},
+		jwt.WithValidMethods([]string{verifier.Algorithm}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("subject is not a user id: %w", err)
+	}
+	role := claims.Role
+	switch role {
+	case "":
+		role = model.RolePlayer
+	case model.RolePlayer, model.RoleEditor, model.RoleAdmin:
+	default:
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+	return &model.Actor{UserID: userID, Role: role}, nil
+}
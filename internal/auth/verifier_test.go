@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/model"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestVerifierVerify(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})
+
+	hs256, err := NewVerifier(HS256, testSecret, nil)
+	if err != nil {
+		t.Fatalf("hs256 verifier: %v", err)
+	}
+	rs256, err := NewVerifier(RS256, "", publicKeyPEM)
+	if err != nil {
+		t.Fatalf("rs256 verifier: %v", err)
+	}
+
+	userID := uuid.New()
+	valid := func(role string) Claims {
+		return Claims{
+			Role: role,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   userID.String(),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+	}
+	expired := valid(model.RolePlayer)
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	withoutExpiration := valid(model.RolePlayer)
+	withoutExpiration.ExpiresAt = nil
+	badSubject := valid(model.RolePlayer)
+	badSubject.Subject = "not-a-uuid"
+
+	signHS256 := func(key []byte) func(Claims) string {
+		return func(claims Claims) string {
+			return sign(t, jwt.SigningMethodHS256, key, claims)
+		}
+	}
+	signRS256 := func(claims Claims) string {
+		return sign(t, jwt.SigningMethodRS256, privateKey, claims)
+	}
+
+	tests := []struct {
+		name     string
+		verifier *Verifier
+		sign     func(Claims) string
+		claims   Claims
+		wantRole string
+		wantErr  bool
+	}{
+		{name: "hs256 valid", verifier: hs256, sign: signHS256([]byte(testSecret)), claims: valid(model.RoleEditor), wantRole: model.RoleEditor},
+		{name: "hs256 role defaults to player", verifier: hs256, sign: signHS256([]byte(testSecret)), claims: valid(""), wantRole: model.RolePlayer},
+		{name: "hs256 expired", verifier: hs256, sign: signHS256([]byte(testSecret)), claims: expired, wantErr: true},
+		{name: "hs256 without expiration", verifier: hs256, sign: signHS256([]byte(testSecret)), claims: withoutExpiration, wantErr: true},
+		{name: "hs256 wrong secret", verifier: hs256, sign: signHS256([]byte("another secret of thirty-two bytes")), claims: valid(model.RolePlayer), wantErr: true},
+		{name: "hs256 wrong algorithm", verifier: hs256, sign: signRS256, claims: valid(model.RolePlayer), wantErr: true},
+		{name: "hs256 bad subject", verifier: hs256, sign: signHS256([]byte(testSecret)), claims: badSubject, wantErr: true},
+		{name: "hs256 unknown role", verifier: hs256, sign: signHS256([]byte(testSecret)), claims: valid("root"), wantErr: true},
+		{name: "rs256 valid", verifier: rs256, sign: signRS256, claims: valid(model.RoleAdmin), wantRole: model.RoleAdmin},
+		{name: "rs256 expired", verifier: rs256, sign: signRS256, claims: expired, wantErr: true},
+		{name: "rs256 wrong algorithm", verifier: rs256, sign: signHS256(publicKeyPEM), claims: valid(model.RoleAdmin), wantErr: true},
+		{name: "rs256 bad subject", verifier: rs256, sign: signRS256, claims: badSubject, wantErr: true},
+		{name: "rs256 unknown role", verifier: rs256, sign: signRS256, claims: valid("root"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor, err := tt.verifier.Verify(tt.sign(tt.claims))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Verify() = %+v, want error", actor)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if actor.UserID != userID || actor.Role != tt.wantRole {
+				t.Errorf("Verify() = %+v, want user %v with role %q", actor, userID, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	tests := []struct {
+		name         string
+		algorithm    string
+		secret       string
+		publicKeyPEM []byte
+	}{
+		{name: "hs256 without secret", algorithm: HS256},
+		{name: "rs256 without public key", algorithm: RS256},
+		{name: "rs256 with malformed public key", algorithm: RS256, publicKeyPEM: []byte("not a key")},
+		{name: "unsupported algorithm", algorithm: "none", secret: testSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewVerifier(tt.algorithm, tt.secret, tt.publicKeyPEM); err == nil {
+				t.Fatal("NewVerifier() error = nil, want error")
+			}
+		})
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
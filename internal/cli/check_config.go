@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"os"
+)
+
+// checkConfig loads configuration the same way serve does and prints every
+// value with its source, secrets are redacted.
+func checkConfig(args []string) error {
+	if err := parseFlags(newFlagSet("check-config"), args); err != nil {
+		return err
+	}
+	config, err := loadServeConfig()
+	config.Dump(os.Stdout)
+	return err
+}
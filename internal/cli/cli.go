@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/mbilarusdev/quiz/internal/app"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"gorm.io/gorm"
+)
+
+// operator is actor of CLI commands. It has admin role and acts as system user
+// with nil id, which owns content created without account.
+var operator = &model.Actor{Role: model.RoleAdmin}
+
+// command is subcommand of quiz binary, name of nested command contains
+// space, e.g. "users create".
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"serve", "", "start HTTP server (default)", serve},
+	{"migrate", "up|down|status|redo", "manage database schema", migrate},
+	{"seed", "", "create demo questions and quiz", seed},
+	{"import", "[-file questions.json]", "create questions with answers from JSON", importQuestions},
+	{"export", "[-file questions.json]", "write questions with answers as JSON", exportQuestions},
+	{"users create", "-email EMAIL [-name NAME] [-role ROLE]", "create user, password is read from stdin", createUser},
+	{"questions list", "[-limit N] [-offset N] [-q TEXT] [-sort SORT]", "print questions", listQuestions},
+	{"check-config", "", "validate configuration", checkConfig},
+}
+
+// configOverrides are global flags of quiz binary, parsed before command name.
+var configOverrides *common.ConfigOverrides
+
+// usageError is returned when command is invoked with wrong arguments.
+type usageError struct {
+	error
+}
+
+// Run executes command from args and returns exit code of process.
+func Run(args []string) int {
+	global := flag.NewFlagSet("quiz", flag.ContinueOnError)
+	global.Usage = func() {
+		printUsage(global.Output())
+		fmt.Fprintln(global.Output(), "\nGlobal flags override config file and environment:")
+		global.PrintDefaults()
+	}
+	configOverrides = common.BindConfigFlags(global)
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	args = global.Args()
+	if len(args) == 1 && args[0] == "help" {
+		global.SetOutput(os.Stdout)
+		global.Usage()
+		return 0
+	}
+	cmd, args, ok := lookup(args)
+	if !ok {
+		printUsage(os.Stderr)
+		return 2
+	}
+	err := cmd.run(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "quiz %v: %v\n", cmd.name, err)
+	var usageErr usageError
+	if errors.As(err, &usageErr) {
+		return 2
+	}
+	return 1
+}
+
+func lookup(args []string) (command, []string, bool) {
+	if len(args) == 0 {
+		return commands[0], args, true
+	}
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func printUsage(out io.Writer) {
+	fmt.Fprintln(out, "Usage: quiz [global flags] command [flags]")
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  quiz %v\t%v\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+	w.Flush()
+}
+
+// newFlagSet creates flags of command, parse errors are reported as
+// usageError.
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("quiz "+name, flag.ContinueOnError)
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{err}
+	}
+	if flags.NArg() > 0 {
+		return usageError{fmt.Errorf("unexpected arguments %v", flags.Args())}
+	}
+	return nil
+}
+
+// loadConfig loads config with global flags applied.
+func loadConfig() (*common.QuizConfig, error) {
+	config, err := common.LoadConfig(configOverrides)
+	if err != nil {
+		return config, fmt.Errorf("invalid config:\n%w", err)
+	}
+	return config, nil
+}
+
+// loadServeConfig is loadConfig which also validates settings of tokens,
+// other commands don't verify tokens and run without them.
+func loadServeConfig() (*common.QuizConfig, error) {
+	config, err := common.LoadConfig(configOverrides)
+	if err := errors.Join(err, config.ValidateAuth()); err != nil {
+		return config, fmt.Errorf("invalid config:\n%w", err)
+	}
+	return config, nil
+}
+
+// withPostgres inits logger and config, connects to postgres and closes
+// connection after run. Context of run is canceled on SIGINT/SIGTERM.
+func withPostgres(run func(ctx context.Context, db *gorm.DB) error) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	app.Init(config)
+	defer common.L.Sync()
+	db, err := app.OpenPostgres()
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return run(ctx, db)
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/migration"
+	"github.com/mbilarusdev/quiz/migrations"
+	"gorm.io/gorm"
+)
+
+// migrate runs goose command with migrations embedded into binary.
+func migrate(args []string) error {
+	if len(args) != 1 || !slices.Contains(migration.Commands, args[0]) {
+		return usageError{fmt.Errorf("expected one of %v", migration.Commands)}
+	}
+	return withPostgres(func(ctx context.Context, db *gorm.DB) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		migrator, err := migration.NewMigrator(sqlDB, migrations.FS, common.Conf.MigrationsTable)
+		if err != nil {
+			return fmt.Errorf("can't load migrations: %w", err)
+		}
+		return migrator.Run(ctx, args[0], os.Stdout)
+	})
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	"github.com/mbilarusdev/quiz/internal/service"
+	"gorm.io/gorm"
+)
+
+func listQuestions(args []string) error {
+	flags := newFlagSet("questions list")
+	params := &model.ListParams{}
+	flags.IntVar(&params.Limit, "limit", 20, "max number of questions, up to 100")
+	flags.IntVar(&params.Offset, "offset", 0, "number of questions to skip")
+	flags.StringVar(&params.Query, "q", "", "filter questions containing text")
+	flags.StringVar(&params.Sort, "sort", model.SortIDAsc, "sort: id, -id, created_at or -created_at")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return withPostgres(func(ctx context.Context, db *gorm.DB) error {
+		questionSrv := service.NewQuestionService(
+			repository.NewQuestionRepository(db),
+			repository.NewAnswerRepository(db),
+			db,
+		)
+		page, err := questionSrv.FindAll(ctx, operator, params)
+		if err != nil {
+			return err
+		}
+		out := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(out, "ID\tTYPE\tVERSION\tCREATED\tTEXT")
+		for _, question := range page.Items {
+			fmt.Fprintf(out, "%v\t%v\t%v\t%v\t%v\n",
+				question.ID,
+				question.Type,
+				question.Version,
+				question.CreatedAt.Format("2006-01-02 15:04"),
+				question.Text)
+		}
+		if err := out.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("Shown %v of %v questions\n", len(page.Items), page.Total)
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	"github.com/mbilarusdev/quiz/internal/service"
+	"gorm.io/gorm"
+)
+
+//go:embed seed.json
+var seedJSON []byte
+
+type seedData struct {
+	Quiz      model.Quiz       `json:"quiz"`
+	Questions []model.Question `json:"questions"`
+}
+
+// seed creates demo questions of every type and quiz of them. It is not
+// idempotent, every run creates new copies.
+func seed(args []string) error {
+	if err := parseFlags(newFlagSet("seed"), args); err != nil {
+		return err
+	}
+	data := &seedData{}
+	if err := json.Unmarshal(seedJSON, data); err != nil {
+		return err
+	}
+	return withPostgres(func(ctx context.Context, db *gorm.DB) error {
+		questionRepo := repository.NewQuestionRepository(db)
+		questionSrv := service.NewQuestionService(questionRepo, repository.NewAnswerRepository(db), db)
+		quizSrv := service.NewQuizService(repository.NewQuizRepository(db), questionRepo, db)
+
+		questionIDs, err := createQuestions(ctx, questionSrv, data.Questions)
+		if err != nil {
+			return err
+		}
+		data.Quiz.QuestionIDs = questionIDs
+		quiz, err := quizSrv.Create(ctx, operator, &data.Quiz)
+		if err != nil {
+			return fmt.Errorf("can't create quiz: %w", err)
+		}
+		fmt.Printf("Created %v questions and quiz with id=%v\n", len(questionIDs), quiz.ID)
+		return nil
+	})
+}
@@ -0,0 +1,14 @@
+package cli
+
+import "github.com/mbilarusdev/quiz/internal/app"
+
+func serve(args []string) error {
+	if err := parseFlags(newFlagSet("serve"), args); err != nil {
+		return err
+	}
+	config, err := loadServeConfig()
+	if err != nil {
+		return err
+	}
+	return app.NewQuizApp(config).Run()
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	"github.com/mbilarusdev/quiz/internal/service"
+	"github.com/mbilarusdev/quiz/internal/util"
+	"gorm.io/gorm"
+)
+
+const exportPageLimit = 100
+
+// importQuestions creates questions from JSON array in format written by
+// export. Identifiers, versions and timestamps of file are ignored, so the
+// same file can be imported several times.
+func importQuestions(args []string) error {
+	flags := newFlagSet("import")
+	file := flags.String("file", "-", "JSON file with questions, '-' reads stdin")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	var questions []model.Question
+	if err := json.NewDecoder(in).Decode(&questions); err != nil {
+		return fmt.Errorf("can't decode questions: %w", err)
+	}
+	return withPostgres(func(ctx context.Context, db *gorm.DB) error {
+		questionSrv := service.NewQuestionService(
+			repository.NewQuestionRepository(db),
+			repository.NewAnswerRepository(db),
+			db,
+		)
+		questionIDs, err := createQuestions(ctx, questionSrv, questions)
+		fmt.Printf("Imported %v of %v questions\n", len(questionIDs), len(questions))
+		return err
+	})
+}
+
+// createQuestions creates every question and returns ids of created ones,
+// failures don't stop creation of the rest and are joined to error.
+func createQuestions(
+	ctx context.Context,
+	questionSrv service.QuestionLogic,
+	questions []model.Question,
+) ([]int, error) {
+	questionIDs := make([]int, 0, len(questions))
+	var errs []error
+	for i := range questions {
+		question := &questions[i]
+		question.ID = 0
+		question.DeletedAt = gorm.DeletedAt{}
+		for j := range question.Answers {
+			question.Answers[j].ID = 0
+			question.Answers[j].QuestionID = 0
+			question.Answers[j].Version = 0
+			question.Answers[j].DeletedAt = gorm.DeletedAt{}
+		}
+		created, err := questionSrv.Create(ctx, operator, question)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("question #%v %q: %w", i+1, question.Text, err))
+			continue
+		}
+		questionIDs = append(questionIDs, created.ID)
+	}
+	return questionIDs, errors.Join(errs...)
+}
+
+// exportQuestions writes not deleted questions with their answers as JSON
+// array ordered by id.
+func exportQuestions(args []string) error {
+	flags := newFlagSet("export")
+	file := flags.String("file", "-", "output file, '-' writes stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return withPostgres(func(ctx context.Context, db *gorm.DB) error {
+		questionSrv := service.NewQuestionService(
+			repository.NewQuestionRepository(db),
+			repository.NewAnswerRepository(db),
+			db,
+		)
+		questions := make([]model.Question, 0)
+		params := &model.ListParams{Limit: exportPageLimit, Sort: model.SortIDAsc}
+		for {
+			page, err := questionSrv.FindAll(ctx, operator, params)
+			if err != nil {
+				return err
+			}
+			for _, item := range page.Items {
+				question, err := questionSrv.FindOneDetailed(ctx, operator, item.ID)
+				if err != nil {
+					return err
+				}
+				questions = append(questions, *question)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			params.Cursor, err = util.DecodeCursor(page.NextCursor)
+			if err != nil {
+				return err
+			}
+		}
+
+		var out io.Writer = os.Stdout
+		if *file != "-" {
+			f, err := os.Create(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(questions); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %v questions\n", len(questions))
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	"github.com/mbilarusdev/quiz/internal/service"
+	"gorm.io/gorm"
+)
+
+// createUser creates account with given role. Password is read from the first
+// line of stdin, so it doesn't show up in shell history and process list.
+func createUser(args []string) error {
+	flags := newFlagSet("users create")
+	email := flags.String("email", "", "email of user")
+	name := flags.String("name", "", "display name of user")
+	role := flags.String("role", model.RolePlayer, "role of user: player, editor or admin")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return usageError{fmt.Errorf("flag -email is required")}
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return fmt.Errorf("can't read password: %w", err)
+	}
+	registration := &model.Registration{
+		Email:       *email,
+		Password:    strings.TrimRight(password, "\r\n"),
+		DisplayName: *name,
+	}
+	return withPostgres(func(ctx context.Context, db *gorm.DB) error {
+		userSrv := service.NewUserService(repository.NewUserRepository(db), nil)
+		user, err := userSrv.Create(ctx, operator, registration, *role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created user %v with id=%v and role '%v'\n", user.Email, user.ID, user.Role)
+		return nil
+	})
+}
@@ -1,44 +1,366 @@
 package common
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"go.uber.org/zap/zapcore"
 )
 
 var Conf *QuizConfig
 
 type Config interface {
-	Parse()
+	Validate() error
 }
 
+// FieldError is problem with value of one config key.
+type FieldError struct {
+	Key string
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// QuizConfig is loaded from several sources, later ones override earlier:
+// defaults < YAML/TOML file < environment (and optional .env) < flags.
+//
+// Tag conf is the key of field in file and, with dashes, the name of flag,
+// env is the name of environment variable. Secret fields are redacted in Dump,
+// secret:"url" hides only password of URL.
 type QuizConfig struct {
-	Env         string
-	PostgresDsn string
-	Addr        string
+	Env      string `conf:"env"       env:"ENV"       default:"development" usage:"environment: development or production"`
+	LogLevel string `conf:"log_level" env:"LOG_LEVEL" default:"debug"       usage:"minimal log level: debug, info, warn or error"`
+
+	LogSamplingInitial    int    `conf:"log_sampling_initial"    env:"LOG_SAMPLING_INITIAL"    default:"0"   usage:"same log lines per second kept before sampling, 0 disables sampling"`
+	LogSamplingThereafter int    `conf:"log_sampling_thereafter" env:"LOG_SAMPLING_THEREAFTER" default:"100" usage:"every Nth same log line kept after initial ones"`
+	LogFile               string `conf:"log_file"                env:"LOG_FILE"                                usage:"also write JSON logs to file with rotation"`
+	LogFileMaxSizeMB      int    `conf:"log_file_max_size_mb"    env:"LOG_FILE_MAX_SIZE_MB"    default:"100" usage:"size of log file to rotate it"`
+	LogFileMaxBackups     int    `conf:"log_file_max_backups"    env:"LOG_FILE_MAX_BACKUPS"    default:"5"   usage:"rotated log files to keep, 0 keeps all"`
+	LogFileMaxAgeDays     int    `conf:"log_file_max_age_days"   env:"LOG_FILE_MAX_AGE_DAYS"   default:"28"  usage:"days to keep rotated log files, 0 keeps forever"`
+
+	PostgresDsn string `conf:"postgres" env:"POSTGRES" required:"true" secret:"url" usage:"postgres connection URL"`
+	Addr        string `conf:"addr"     env:"ADDR"     default:":8080"              usage:"HTTP listen address"`
+
+	HttpReadTimeout    time.Duration `conf:"http_read_timeout"     env:"HTTP_READ_TIMEOUT"     default:"5s"      usage:"max duration of reading request"`
+	HttpWriteTimeout   time.Duration `conf:"http_write_timeout"    env:"HTTP_WRITE_TIMEOUT"    default:"10s"     usage:"max duration of writing response"`
+	HttpMaxHeaderBytes int           `conf:"http_max_header_bytes" env:"HTTP_MAX_HEADER_BYTES" default:"1048576" usage:"max size of request headers"`
+	ShutdownTimeout    time.Duration `conf:"shutdown_timeout"      env:"SHUTDOWN_TIMEOUT"      default:"15s"     usage:"time to drain requests on shutdown"`
+
+	DbMaxOpenConns      int           `conf:"db_max_open_conns"      env:"DB_MAX_OPEN_CONNS"      default:"10"    usage:"max open postgres connections"`
+	DbMaxIdleConns      int           `conf:"db_max_idle_conns"      env:"DB_MAX_IDLE_CONNS"      default:"5"     usage:"max idle postgres connections"`
+	DbConnMaxLifetime   time.Duration `conf:"db_conn_max_lifetime"   env:"DB_CONN_MAX_LIFETIME"   default:"30m"   usage:"max lifetime of postgres connection"`
+	DbConnectAttempts   int           `conf:"db_connect_attempts"    env:"DB_CONNECT_ATTEMPTS"    default:"10"    usage:"attempts to connect postgres on start"`
+	DbConnectBackoff    time.Duration `conf:"db_connect_backoff"     env:"DB_CONNECT_BACKOFF"     default:"500ms" usage:"initial delay between connect attempts"`
+	DbConnectMaxBackoff time.Duration `conf:"db_connect_max_backoff" env:"DB_CONNECT_MAX_BACKOFF" default:"10s"   usage:"max delay between connect attempts"`
+	DbQueryTimeout      time.Duration `conf:"db_query_timeout"       env:"DB_QUERY_TIMEOUT"       default:"5s"    usage:"max duration of one database query"`
+
+	MigrationsTable string `conf:"migrations_table" env:"GOOSE_TABLE"      default:"quiz_goose_migrations" usage:"table of applied migrations"`
+	MigrateOnStart  bool   `conf:"migrate_on_start" env:"MIGRATE_ON_START" default:"false"                 usage:"apply migrations on server start"`
+
+	AttemptSweepInterval time.Duration `conf:"attempt_sweep_interval" env:"ATTEMPT_SWEEP_INTERVAL" default:"30s" usage:"interval of closing expired attempts"`
+
+	JwtAlgorithm      string        `conf:"jwt_algorithm"        env:"JWT_ALGORITHM"        default:"HS256"     usage:"JWT algorithm: HS256 or RS256"`
+	JwtSecret         string        `conf:"jwt_secret"           env:"JWT_SECRET"           secret:"true"       usage:"JWT secret for HS256"`
+	JwtPublicKeyFile  string        `conf:"jwt_public_key_file"  env:"JWT_PUBLIC_KEY_FILE"                      usage:"PEM public key for RS256"`
+	JwtPrivateKeyFile string        `conf:"jwt_private_key_file" env:"JWT_PRIVATE_KEY_FILE"                     usage:"PEM private key for RS256"`
+	JwtTTL            time.Duration `conf:"jwt_ttl"              env:"JWT_TTL"              default:"24h"       usage:"lifetime of issued tokens"`
+
+	// sources keeps where value of every key came from, for Dump
+	sources map[string]string
+	// failed keeps keys which couldn't be loaded, Validate skips checks of them
+	failed map[string]bool
+}
+
+// ConfigOverrides are values of command line flags, they take precedence
+// over every other source.
+type ConfigOverrides struct {
+	File   string
+	Values map[string]string
+}
+
+// minJwtSecretLength is 256 bits, the size of HS256 signature.
+const minJwtSecretLength = 32
+
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+)
+
+type configField struct {
+	index    int
+	key      string
+	env      string
+	def      string
+	usage    string
+	secret   string
+	required bool
 }
 
-func NewQuizConfig() *QuizConfig {
-	config := new(QuizConfig)
-	config.parse()
-	return config
+func configFields() []configField {
+	t := reflect.TypeFor[QuizConfig]()
+	fields := make([]configField, 0, t.NumField())
+	for i := range t.NumField() {
+		tag := t.Field(i).Tag
+		key, ok := tag.Lookup("conf")
+		if !ok {
+			continue
+		}
+		fields = append(fields, configField{
+			index:    i,
+			key:      key,
+			env:      tag.Get("env"),
+			def:      tag.Get("default"),
+			usage:    tag.Get("usage"),
+			secret:   tag.Get("secret"),
+			required: tag.Get("required") == "true",
+		})
+	}
+	return fields
 }
 
-func (config *QuizConfig) parse() {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Fatal("Error when loading .env file:", err)
+// BindConfigFlags registers -config and a flag for every config key.
+func BindConfigFlags(flags FlagSet) *ConfigOverrides {
+	overrides := &ConfigOverrides{Values: make(map[string]string)}
+	flags.StringVar(&overrides.File, "config", os.Getenv("QUIZ_CONFIG"), "YAML or TOML config file")
+	for _, field := range configFields() {
+		key := field.key
+		usage := field.usage
+		if field.env != "" {
+			usage = fmt.Sprintf("%v (env %v)", usage, field.env)
+		}
+		flags.Func(strings.ReplaceAll(key, "_", "-"), usage, func(value string) error {
+			overrides.Values[key] = value
+			return nil
+		})
+	}
+	return overrides
+}
+
+// FlagSet is subset of flag.FlagSet used to bind config flags.
+type FlagSet interface {
+	StringVar(p *string, name string, value string, usage string)
+	Func(name string, usage string, fn func(string) error)
+}
+
+// LoadConfig merges all sources into config. All problems found are returned
+// joined, so they can be fixed at once.
+func LoadConfig(overrides *ConfigOverrides) (*QuizConfig, error) {
+	if overrides == nil {
+		overrides = &ConfigOverrides{}
+	}
+	var errs []error
+	fields := configFields()
+	raw := make(map[string]string, len(fields))
+	sources := make(map[string]string, len(fields))
+	set := func(key string, value string, source string) {
+		raw[key] = value
+		sources[key] = source
+	}
+
+	for _, field := range fields {
+		if field.def != "" {
+			set(field.key, field.def, sourceDefault)
+		}
 	}
 
-	config.PostgresDsn = parseVar("POSTGRES")
-	config.Addr = parseVar("ADDR")
+	if overrides.File != "" {
+		values, err := readConfigFile(overrides.File)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for key, value := range values {
+			set(key, value, sourceFile)
+		}
+	}
+
+	// .env is optional, e.g. in Kubernetes variables come from environment,
+	// it never overrides variables already set
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, fmt.Errorf("can't load .env: %w", err))
+	}
+	for _, field := range fields {
+		if value := os.Getenv(field.env); field.env != "" && value != "" {
+			set(field.key, value, sourceEnv)
+		}
+	}
+
+	for key, value := range overrides.Values {
+		set(key, value, sourceFlag)
+	}
+
+	config := &QuizConfig{sources: sources, failed: make(map[string]bool)}
+	known := make(map[string]bool, len(fields))
+	target := reflect.ValueOf(config).Elem()
+	for _, field := range fields {
+		known[field.key] = true
+		value, ok := raw[field.key]
+		if !ok || value == "" {
+			if field.required {
+				errs = append(errs, &FieldError{Key: field.key, Err: fmt.Errorf("%v is required", field.name())})
+				config.failed[field.key] = true
+			}
+			continue
+		}
+		if err := setField(target.Field(field.index), value); err != nil {
+			errs = append(errs, &FieldError{
+				Key: field.key,
+				Err: fmt.Errorf("%v from %v: %w", field.name(), sources[field.key], err),
+			})
+			config.failed[field.key] = true
+		}
+	}
+	for key, source := range sources {
+		if !known[key] {
+			errs = append(errs, fmt.Errorf("unknown key %q in %v", key, source))
+		}
+	}
+	if err := config.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return config, errors.Join(errs...)
+}
+
+// Validate checks relations between values, every violation is reported as
+// FieldError. Keys which failed to load are not checked, error of loading
+// the key is already reported.
+func (config *QuizConfig) Validate() error {
+	var errs []error
+	check := config.checker(&errs)
+	check("env", config.Env == "development" || config.Env == "production",
+		"env must be development or production, got %q", config.Env)
+	_, err := zapcore.ParseLevel(config.LogLevel)
+	check("log_level", err == nil, "log_level %q is unknown", config.LogLevel)
+	check("log_sampling_initial", config.LogSamplingInitial >= 0, "log_sampling_initial must not be negative")
+	check("log_sampling_thereafter", config.LogSamplingThereafter > 0, "log_sampling_thereafter must be positive")
+	check("log_file_max_size_mb", config.LogFileMaxSizeMB > 0, "log_file_max_size_mb must be positive")
+	check("log_file_max_backups", config.LogFileMaxBackups >= 0, "log_file_max_backups must not be negative")
+	check("log_file_max_age_days", config.LogFileMaxAgeDays >= 0, "log_file_max_age_days must not be negative")
+	_, err = url.Parse(config.PostgresDsn)
+	check("postgres", err == nil, "postgres must be URL")
+
+	check("http_read_timeout", config.HttpReadTimeout > 0, "http_read_timeout must be positive")
+	check("http_write_timeout", config.HttpWriteTimeout > 0, "http_write_timeout must be positive")
+	check("http_max_header_bytes", config.HttpMaxHeaderBytes > 0, "http_max_header_bytes must be positive")
+	check("shutdown_timeout", config.ShutdownTimeout > 0, "shutdown_timeout must be positive")
+
+	check("db_max_open_conns", config.DbMaxOpenConns > 0, "db_max_open_conns must be positive")
+	if !config.failed["db_max_open_conns"] {
+		check("db_max_idle_conns", config.DbMaxIdleConns >= 0 && config.DbMaxIdleConns <= config.DbMaxOpenConns,
+			"db_max_idle_conns must be between 0 and db_max_open_conns")
+	}
+	check("db_conn_max_lifetime", config.DbConnMaxLifetime >= 0, "db_conn_max_lifetime must not be negative")
+	check("db_connect_attempts", config.DbConnectAttempts > 0, "db_connect_attempts must be positive")
+	check("db_connect_backoff", config.DbConnectBackoff > 0, "db_connect_backoff must be positive")
+	if !config.failed["db_connect_backoff"] {
+		check("db_connect_max_backoff", config.DbConnectMaxBackoff >= config.DbConnectBackoff,
+			"db_connect_max_backoff must not be less than db_connect_backoff")
+	}
+	check("db_query_timeout", config.DbQueryTimeout > 0, "db_query_timeout must be positive")
+
+	check("attempt_sweep_interval", config.AttemptSweepInterval > 0, "attempt_sweep_interval must be positive")
+	return errors.Join(errs...)
+}
+
+// ValidateAuth checks settings of issuing and verifying tokens, only server
+// needs them, so commands like migrate run without JWT secret.
+func (config *QuizConfig) ValidateAuth() error {
+	var errs []error
+	check := config.checker(&errs)
+	switch config.JwtAlgorithm {
+	case "HS256":
+		check("jwt_secret", len(config.JwtSecret) >= minJwtSecretLength,
+			"jwt_secret of at least %v bytes is required for HS256", minJwtSecretLength)
+	case "RS256":
+		check("jwt_public_key_file", config.JwtPublicKeyFile != "", "jwt_public_key_file is required for RS256")
+		check("jwt_private_key_file", config.JwtPrivateKeyFile != "", "jwt_private_key_file is required for RS256")
+	default:
+		check("jwt_algorithm", false, "jwt_algorithm must be HS256 or RS256, got %q", config.JwtAlgorithm)
+	}
+	check("jwt_ttl", config.JwtTTL > 0, "jwt_ttl must be positive")
+	return errors.Join(errs...)
+}
+
+// checker returns function which appends FieldError of key to errs when
+// check is not ok, unless key failed to load.
+func (config *QuizConfig) checker(errs *[]error) func(key string, ok bool, format string, args ...any) {
+	return func(key string, ok bool, format string, args ...any) {
+		if !ok && !config.failed[key] {
+			*errs = append(*errs, &FieldError{Key: key, Err: fmt.Errorf(format, args...)})
+		}
+	}
+}
+
+// Dump writes every key with its value and source, secrets are redacted.
+func (config *QuizConfig) Dump(out io.Writer) {
+	value := reflect.ValueOf(config).Elem()
+	for _, field := range configFields() {
+		printed := fmt.Sprint(value.Field(field.index).Interface())
+		switch {
+		case printed == "":
+		case field.secret == "url":
+			if parsed, err := url.Parse(printed); err == nil {
+				printed = parsed.Redacted()
+			} else {
+				printed = "[redacted]"
+			}
+		case field.secret != "":
+			printed = "[redacted]"
+		}
+		source := config.sources[field.key]
+		if source == "" {
+			source = "unset"
+		}
+		fmt.Fprintf(out, "%v = %q (%v)\n", field.key, printed, source)
+	}
+}
+
+func (field configField) name() string {
+	if field.env != "" {
+		return fmt.Sprintf("%v (%v)", field.key, field.env)
+	}
+	return field.key
 }
 
-func parseVar(varName string) string {
-	variable := os.Getenv(varName)
-	if variable == "" {
-		panic(varName + " not provided")
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		field.SetBool(parsed)
+	case int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		field.SetInt(int64(parsed))
+	case time.Duration:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+		field.SetInt(int64(parsed))
+	default:
+		return fmt.Errorf("unsupported type %v", field.Type())
 	}
-	return variable
+	return nil
 }
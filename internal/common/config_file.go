@@ -0,0 +1,42 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// readConfigFile reads flat YAML or TOML file, format is chosen by extension.
+// Values are returned as strings to be parsed like environment variables.
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read config file: %w", err)
+	}
+	document := make(map[string]any)
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &document)
+	case ".toml":
+		err = toml.Unmarshal(content, &document)
+	default:
+		return nil, fmt.Errorf("config file %v must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't parse config file %v: %w", path, err)
+	}
+	values := make(map[string]string, len(document))
+	for key, value := range document {
+		switch value.(type) {
+		case map[string]any, []any:
+			return nil, fmt.Errorf("key %q of config file %v must be scalar", key, path)
+		case nil:
+			continue
+		}
+		values[key] = fmt.Sprint(value)
+	}
+	return values, nil
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		wantKeys []string
+	}{
+		{
+			name:   "valid without jwt settings",
+			values: map[string]string{"postgres": "postgres://quiz@localhost/quiz"},
+		},
+		{
+			name:     "missing required key",
+			values:   map[string]string{},
+			wantKeys: []string{"postgres"},
+		},
+		{
+			name: "unparsable key skips checks relying on it",
+			values: map[string]string{
+				"postgres":          "postgres://quiz@localhost/quiz",
+				"db_max_open_conns": "many",
+				"db_max_idle_conns": "5",
+			},
+			wantKeys: []string{"db_max_open_conns"},
+		},
+		{
+			name: "every violation is reported",
+			values: map[string]string{
+				"postgres":          "postgres://quiz@localhost/quiz",
+				"env":               "staging",
+				"db_max_open_conns": "2",
+				"db_max_idle_conns": "5",
+			},
+			wantKeys: []string{"env", "db_max_idle_conns"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POSTGRES", "")
+			_, err := LoadConfig(&ConfigOverrides{Values: tt.values})
+			if keys := fieldErrorKeys(t, err); !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("LoadConfig() failed keys = %v, want %v, error %v", keys, tt.wantKeys, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		wantKeys []string
+	}{
+		{
+			name:   "hs256 with long secret",
+			values: map[string]string{"jwt_secret": "0123456789abcdef0123456789abcdef"},
+		},
+		{
+			name:     "hs256 without secret",
+			values:   map[string]string{},
+			wantKeys: []string{"jwt_secret"},
+		},
+		{
+			name:     "hs256 with short secret",
+			values:   map[string]string{"jwt_secret": "secret"},
+			wantKeys: []string{"jwt_secret"},
+		},
+		{
+			name:     "rs256 without private key",
+			values:   map[string]string{"jwt_algorithm": "RS256", "jwt_public_key_file": "public.pem"},
+			wantKeys: []string{"jwt_private_key_file"},
+		},
+		{
+			name:     "unknown algorithm",
+			values:   map[string]string{"jwt_algorithm": "none"},
+			wantKeys: []string{"jwt_algorithm"},
+		},
+		{
+			name:     "unparsable ttl",
+			values:   map[string]string{"jwt_secret": "0123456789abcdef0123456789abcdef", "jwt_ttl": "day"},
+			wantKeys: []string{"jwt_ttl"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "")
+			values := map[string]string{"postgres": "postgres://quiz@localhost/quiz"}
+			for key, value := range tt.values {
+				values[key] = value
+			}
+			config, err := LoadConfig(&ConfigOverrides{Values: values})
+			err = errors.Join(err, config.ValidateAuth())
+			if keys := fieldErrorKeys(t, err); !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("ValidateAuth() failed keys = %v, want %v, error %v", keys, tt.wantKeys, err)
+			}
+		})
+	}
+}
+
+// fieldErrorKeys returns keys of joined field errors in order of reporting.
+func fieldErrorKeys(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var keys []string
+	var collect func(err error)
+	collect = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				collect(err)
+			}
+			return
+		}
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Fatalf("error %v is not FieldError", err)
+		}
+		keys = append(keys, fieldErr.Key)
+	}
+	collect(err)
+	return keys
+}
@@ -1,16 +1,66 @@
 package common
 
 import (
+	"os"
+	"time"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var L *zap.Logger
 
+// Level is minimal level of L, it can be changed at runtime.
+var Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+
+// InitLogger builds L from Conf. Development logger writes colorized console
+// lines, production one writes JSON. Log file, when configured, always
+// receives JSON and is rotated by size.
 func InitLogger() {
-	cfg := zap.NewDevelopmentConfig()
-	cfg.DisableStacktrace = true
-	cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-	cfg.Level.SetLevel(zap.DebugLevel)
-	L, _ = cfg.Build()
+	config := Conf
+	if config == nil {
+		config = &QuizConfig{Env: "development", LogLevel: "debug"}
+	}
+	if level, err := zapcore.ParseLevel(config.LogLevel); err == nil {
+		Level.SetLevel(level)
+	}
+
+	production := config.Env == "production"
+	jsonConfig := zap.NewProductionEncoderConfig()
+	jsonConfig.TimeKey = "time"
+	jsonConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var consoleEncoder zapcore.Encoder
+	if production {
+		consoleEncoder = zapcore.NewJSONEncoder(jsonConfig)
+	} else {
+		consoleConfig := zap.NewDevelopmentEncoderConfig()
+		consoleConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		consoleEncoder = zapcore.NewConsoleEncoder(consoleConfig)
+	}
+	cores := []zapcore.Core{
+		zapcore.NewCore(consoleEncoder, zapcore.Lock(os.Stderr), Level),
+	}
+	if config.LogFile != "" {
+		file := &lumberjack.Logger{
+			Filename:   config.LogFile,
+			MaxSize:    config.LogFileMaxSizeMB,
+			MaxBackups: config.LogFileMaxBackups,
+			MaxAge:     config.LogFileMaxAgeDays,
+		}
+		cores = append(cores, zapcore.NewCore(zapcore.NewJSONEncoder(jsonConfig), zapcore.AddSync(file), Level))
+	}
+	core := zapcore.NewTee(cores...)
+	if config.LogSamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, config.LogSamplingInitial, config.LogSamplingThereafter)
+	}
+
+	options := []zap.Option{zap.AddCaller()}
+	if production {
+		options = append(options, zap.AddStacktrace(zap.DPanicLevel))
+	} else {
+		options = append(options, zap.Development())
+	}
+	L = zap.New(core, options...)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/mbilarusdev/quiz/internal/model"
 	"github.com/mbilarusdev/quiz/internal/service"
@@ -27,6 +28,30 @@ type AnswerEndpoints interface {
 		w http.ResponseWriter,
 		r *http.Request,
 	)
+	Update(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Patch(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindTrash(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Restore(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindByQuestion(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindByUser(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
 }
 
 type AnswerHandler struct {
@@ -56,6 +81,10 @@ func (res *AnswerHandler) AddAnswer(
 			)
 		}
 	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
@@ -115,8 +144,21 @@ func (res *AnswerHandler) AddAnswer(
 	} else {
 		answer.QuestionID = id
 	}
-	newAnswer, err := res.AnswerSrv.AddAnswer(answer)
+	answer.UserID = actor.UserID
+	newAnswer, err := res.AnswerSrv.AddAnswer(r.Context(), actor, answer)
 	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if unknownUserErr, ok := err.(*service_errors.UnknownUserError); ok {
+			sendUnknownUser(w, r, op, unknownUserErr)
+			return
+		}
 		if _, ok := err.(*service_errors.NotFoundError); ok {
 			util.SendError(
 				model.SendError{
@@ -131,6 +173,17 @@ func (res *AnswerHandler) AddAnswer(
 					StatusCode: http.StatusUnprocessableEntity,
 				},
 			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
 		} else if _, ok := err.(*service_errors.DuplicateError); ok {
 			util.SendError(
 				model.SendError{
@@ -202,6 +255,10 @@ func (res *AnswerHandler) FindOne(
 			)
 		}
 	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
@@ -217,8 +274,16 @@ func (res *AnswerHandler) FindOne(
 		)
 		return
 	}
-	answer, err := res.AnswerSrv.FindOne(id)
+	answer, err := res.AnswerSrv.FindOne(r.Context(), actor, id)
 	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
 		if _, ok := err.(*service_errors.NotFoundError); ok {
 			util.SendError(
 				model.SendError{
@@ -251,6 +316,22 @@ func (res *AnswerHandler) FindOne(
 		}
 		return
 	}
+	etag := util.ETag(answer.Version)
+	w.Header().Set("ETag", etag)
+	if util.IfNoneMatch(r.Header.Get("If-None-Match"), etag) {
+		util.SendSuccess(model.SendSuccess{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			Bytes:       make([]byte, 0),
+			ResultMsg: fmt.Sprintf(
+				"'Answer' with id=%v not modified",
+				id,
+			),
+			StatusCode: http.StatusNotModified,
+		})
+		return
+	}
 	jsonAnswer, err := json.Marshal(answer)
 	if err != nil {
 		util.SendError(
@@ -296,6 +377,10 @@ func (res *AnswerHandler) Delete(
 			)
 		}
 	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
@@ -311,8 +396,46 @@ func (res *AnswerHandler) Delete(
 		)
 		return
 	}
-	deleted, err := res.AnswerSrv.Delete(id)
+	hard := false
+	if value := r.URL.Query().Get("hard"); value != "" {
+		hard, err = strconv.ParseBool(value)
+		if err != nil {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to parse query parameter 'hard'",
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+			return
+		}
+	}
+	version, ok := ifMatchVersion(w, r, op)
+	if !ok {
+		return
+	}
+	var deleted bool
+	if hard {
+		deleted, err = res.AnswerSrv.Purge(r.Context(), actor, id, version)
+	} else {
+		deleted, err = res.AnswerSrv.Delete(r.Context(), actor, id, version)
+	}
 	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if conflictErr, ok := err.(*service_errors.ConflictError); ok {
+			sendConflict(w, r, op, conflictErr)
+			return
+		}
 		util.SendError(
 			model.SendError{
 				W:           w,
@@ -351,3 +474,766 @@ func (res *AnswerHandler) Delete(
 		StatusCode: http.StatusNoContent,
 	})
 }
+
+func (res *AnswerHandler) FindByQuestion(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AnswerHandler.FindByQuestion"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	params, err := util.ParseListParams(r.URL.Query())
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("Failed to parse %v", err.Error()),
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	page, err := res.AnswerSrv.FindByQuestion(r.Context(), actor, id, params)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Question' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to find answers of 'Question' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAnswers, err := json.Marshal(page)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with page of 'Answer'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAnswers,
+		ResultMsg: fmt.Sprintf(
+			"Page of %v 'Answer' from %v of 'Question' with id=%v finded succesfully",
+			len(page.Items),
+			page.Total,
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AnswerHandler) FindByUser(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AnswerHandler.FindByUser"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {user_id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	params, err := util.ParseListParams(r.URL.Query())
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("Failed to parse %v", err.Error()),
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	page, err := res.AnswerSrv.FindByUser(r.Context(), actor, userID, params)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to find answers of 'User' with id=%v, some error occured",
+						userID,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAnswers, err := json.Marshal(page)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with page of 'Answer'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAnswers,
+		ResultMsg: fmt.Sprintf(
+			"Page of %v 'Answer' from %v of 'User' with id=%v finded succesfully",
+			len(page.Items),
+			page.Total,
+			userID,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AnswerHandler) Update(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AnswerHandler.Update"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	version, ok := ifMatchVersion(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	answer := &model.Answer{}
+	err = json.Unmarshal(bodyBytes, answer)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Answer' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	updatedAnswer, err := res.AnswerSrv.Update(r.Context(), actor, id, version, answer)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Answer' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if conflictErr, ok := err.(*service_errors.ConflictError); ok {
+			sendConflict(w, r, op, conflictErr)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to update 'Answer' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAnswer, err := json.Marshal(updatedAnswer)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Answer'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	w.Header().Set("ETag", util.ETag(updatedAnswer.Version))
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAnswer,
+		ResultMsg: fmt.Sprintf(
+			"'Answer' with id=%v updated succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AnswerHandler) Patch(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AnswerHandler.Patch"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	version, ok := ifMatchVersion(w, r, op)
+	if !ok {
+		return
+	}
+	if !util.IsMergePatch(r.Header.Get("Content-Type")) {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Content-Type must be '%v' or 'application/json'",
+					util.MergePatchContentType,
+				),
+				Error:      fmt.Errorf("unsupported Content-Type %q", r.Header.Get("Content-Type")),
+				StatusCode: http.StatusUnsupportedMediaType,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	updatedAnswer, err := res.AnswerSrv.Patch(r.Context(), actor, id, version, bodyBytes)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Answer' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if conflictErr, ok := err.(*service_errors.ConflictError); ok {
+			sendConflict(w, r, op, conflictErr)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to update 'Answer' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAnswer, err := json.Marshal(updatedAnswer)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Answer'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	w.Header().Set("ETag", util.ETag(updatedAnswer.Version))
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAnswer,
+		ResultMsg: fmt.Sprintf(
+			"'Answer' with id=%v updated succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AnswerHandler) FindTrash(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AnswerHandler.FindTrash"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	params, err := util.ParseListParams(r.URL.Query())
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("Failed to parse %v", err.Error()),
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	page, err := res.AnswerSrv.FindTrash(r.Context(), actor, params)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to find deleted answers, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAnswers, err := json.Marshal(page)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with page of deleted 'Answer'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAnswers,
+		ResultMsg: fmt.Sprintf(
+			"Page of %v deleted 'Answer' from %v finded succesfully",
+			len(page.Items),
+			page.Total,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AnswerHandler) Restore(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AnswerHandler.Restore"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	restoredAnswer, err := res.AnswerSrv.Restore(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("Deleted 'Answer' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if stateErr, ok := err.(*service_errors.StateError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to restore 'Answer' with id=%v, because it is %v",
+						id,
+						stateErr.State,
+					),
+					Error:      err,
+					StatusCode: http.StatusConflict,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to restore 'Answer' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAnswer, err := json.Marshal(restoredAnswer)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Answer'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	w.Header().Set("ETag", util.ETag(restoredAnswer.Version))
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAnswer,
+		ResultMsg: fmt.Sprintf(
+			"'Answer' with id=%v restored succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/auth"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+)
+
+// fakeAnswerLogic fails to add answer with fixed error, methods not used by
+// test panic on nil embedded interface.
+type fakeAnswerLogic struct {
+	service.AnswerLogic
+	err error
+}
+
+func (logic *fakeAnswerLogic) AddAnswer(ctx context.Context, actor *model.Actor, answer *model.Answer) (*model.Answer, error) {
+	return nil, logic.err
+}
+
+func TestAnswerHandlerAddAnswerOfUserWithoutAccount(t *testing.T) {
+	userID := uuid.New()
+	handler := NewAnswerHandler(&fakeAnswerLogic{err: &service_errors.UnknownUserError{UserID: userID}})
+	r := httptest.NewRequest(http.MethodPost, "/questions/1/answers", strings.NewReader(`{"text":"Paris"}`))
+	r = mux.SetURLVars(r, map[string]string{"id": "1"})
+	r = r.WithContext(auth.WithActor(r.Context(), &model.Actor{UserID: userID, Role: model.RolePlayer}))
+	w := httptest.NewRecorder()
+
+	handler.AddAnswer(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %v, want %v, body %s", w.Code, http.StatusForbidden, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "has no account") {
+		t.Errorf("body %s doesn't explain that user has no account", w.Body)
+	}
+}
@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type APIKeyEndpoints interface {
+	Issue(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindAll(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Revoke(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type APIKeyHandler struct {
+	APIKeySrv service.APIKeyLogic
+}
+
+func NewAPIKeyHandler(apiKeySrv service.APIKeyLogic) *APIKeyHandler {
+	res := new(APIKeyHandler)
+	res.APIKeySrv = apiKeySrv
+	return res
+}
+
+func (res *APIKeyHandler) Issue(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.APIKeyHandler.Issue"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	key := &model.APIKey{}
+	err = json.Unmarshal(bodyBytes, key)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'APIKey' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	newKey, err := res.APIKeySrv.Issue(r.Context(), actor, key)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to issue api key, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonKey, err := json.Marshal(newKey)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'APIKey'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonKey,
+		ResultMsg: fmt.Sprintf(
+			"'APIKey' with id=%v issued succesfully",
+			newKey.ID,
+		),
+		StatusCode: http.StatusCreated,
+	})
+}
+
+func (res *APIKeyHandler) FindAll(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.APIKeyHandler.FindAll"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	keys, err := res.APIKeySrv.FindAll(r.Context(), actor)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to find api keys, some error occured",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+	jsonKeys, err := json.Marshal(keys)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with list of 'APIKey'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonKeys,
+		ResultMsg: fmt.Sprintf(
+			"%v 'APIKey' finded succesfully",
+			len(keys),
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *APIKeyHandler) Revoke(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.APIKeyHandler.Revoke"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	revoked, err := res.APIKeySrv.Revoke(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Failed to revoke 'APIKey' with id=%v, some error occured",
+					id,
+				),
+				Error:      err,
+				StatusCode: http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+	if !revoked {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("Active 'APIKey' with id=%v not found!", id),
+				Error:       &service_errors.NotFoundError{ID: id, Entity: "APIKey"},
+				StatusCode:  http.StatusNotFound,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       make([]byte, 0),
+		ResultMsg: fmt.Sprintf(
+			"'APIKey' with id=%v revoked succesfully",
+			id,
+		),
+		StatusCode: http.StatusNoContent,
+	})
+}
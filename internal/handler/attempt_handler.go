@@ -0,0 +1,723 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type AttemptEndpoints interface {
+	Start(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindOne(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Respond(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Finish(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Abandon(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Next(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type AttemptHandler struct {
+	AttemptSrv service.AttemptLogic
+}
+
+func NewAttemptHandler(attemptSrv service.AttemptLogic) *AttemptHandler {
+	res := new(AttemptHandler)
+	res.AttemptSrv = attemptSrv
+	return res
+}
+
+func (res *AttemptHandler) Start(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AttemptHandler.Start"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	vars := mux.Vars(r)
+	quizID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	attempt := &model.Attempt{}
+	err = json.Unmarshal(bodyBytes, attempt)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Attempt' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	attempt.UserID = actor.UserID
+	newAttempt, err := res.AttemptSrv.Start(r.Context(), quizID, attempt)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to start attempt, but 'Quiz' with id=%v not found",
+						quizID,
+					),
+					Error:      err,
+					StatusCode: http.StatusNotFound,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to start attempt, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAttempt, err := json.Marshal(newAttempt)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Attempt'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAttempt,
+		ResultMsg: fmt.Sprintf(
+			"'Attempt' with id=%v for 'Quiz' with id=%v started succesfully",
+			newAttempt.ID,
+			quizID,
+		),
+		StatusCode: http.StatusCreated,
+	})
+}
+
+func (res *AttemptHandler) FindOne(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AttemptHandler.FindOne"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	attempt, err := res.AttemptSrv.FindOne(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Attempt' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Error when try to find 'Attempt' with id=%v",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonAttempt, err := json.Marshal(attempt)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Attempt'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAttempt,
+		ResultMsg: fmt.Sprintf(
+			"'Attempt' with id=%v finded succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AttemptHandler) Respond(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AttemptHandler.Respond"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	response := &model.AttemptResponse{}
+	err = json.Unmarshal(bodyBytes, response)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'AttemptResponse' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	newResponse, err := res.AttemptSrv.Respond(r.Context(), actor, id, response)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendAttemptError(w, r, op, id, "answer", err)
+		return
+	}
+	jsonResponse, err := json.Marshal(newResponse)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'AttemptResponse'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonResponse,
+		ResultMsg: fmt.Sprintf(
+			"'Question' with id=%v of 'Attempt' with id=%v answered succesfully",
+			newResponse.QuestionID,
+			id,
+		),
+		StatusCode: http.StatusCreated,
+	})
+}
+
+func (res *AttemptHandler) Finish(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AttemptHandler.Finish"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	attempt, err := res.AttemptSrv.Finish(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendAttemptError(w, r, op, id, "finish", err)
+		return
+	}
+	jsonAttempt, err := json.Marshal(attempt)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Attempt'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAttempt,
+		ResultMsg: fmt.Sprintf(
+			"'Attempt' with id=%v finished succesfully with score %v/%v",
+			id,
+			attempt.Score,
+			attempt.MaxScore,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AttemptHandler) Abandon(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AttemptHandler.Abandon"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	attempt, err := res.AttemptSrv.Abandon(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendAttemptError(w, r, op, id, "abandon", err)
+		return
+	}
+	jsonAttempt, err := json.Marshal(attempt)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Attempt'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonAttempt,
+		ResultMsg: fmt.Sprintf(
+			"'Attempt' with id=%v abandoned succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *AttemptHandler) Next(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.AttemptHandler.Next"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	question, err := res.AttemptSrv.Next(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendAttemptError(w, r, op, id, "serve next question of", err)
+		return
+	}
+	if question == nil {
+		util.SendSuccess(model.SendSuccess{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			Bytes:       make([]byte, 0),
+			ResultMsg: fmt.Sprintf(
+				"'Attempt' with id=%v has no more questions",
+				id,
+			),
+			StatusCode: http.StatusNoContent,
+		})
+		return
+	}
+	// Question is being asked, so its answer key is never shown
+	question.HideKey()
+	jsonQuestion, err := json.Marshal(question)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestion,
+		ResultMsg: fmt.Sprintf(
+			"'Question' with id=%v of 'Attempt' with id=%v served succesfully",
+			question.ID,
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+// sendAttemptError maps errors of attempt state transitions to responses.
+func sendAttemptError(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	id int,
+	action string,
+	err error,
+) {
+	if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+		sendForbidden(w, r, op, forbiddenErr)
+	} else if notFoundErr, ok := err.(*service_errors.NotFoundError); ok {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("'%v' with id=%v not found!", notFoundErr.Entity, notFoundErr.ID),
+				Error:       err,
+				StatusCode:  http.StatusNotFound,
+			},
+		)
+	} else if stateErr, ok := err.(*service_errors.StateError); ok {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Failed to %v 'Attempt' with id=%v, because it is %v",
+					action,
+					id,
+					stateErr.State,
+				),
+				Error:      err,
+				StatusCode: http.StatusConflict,
+			},
+		)
+	} else if deadlineErr, ok := err.(*service_errors.DeadlineError); ok {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Failed to %v 'Attempt' with id=%v, deadline %v has passed",
+					action,
+					id,
+					deadlineErr.Deadline.Format(time.RFC3339),
+				),
+				Error:      err,
+				StatusCode: http.StatusUnprocessableEntity,
+			},
+		)
+	} else if _, ok := err.(*service_errors.DuplicateError); ok {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Failed to %v, question of 'Attempt' with id=%v already answered",
+					action,
+					id,
+				),
+				Error:      err,
+				StatusCode: http.StatusConflict,
+			},
+		)
+	} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    validationErr.Reason,
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+	} else {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Failed to %v 'Attempt' with id=%v, some error occured",
+					action,
+					id,
+				),
+				Error:      err,
+				StatusCode: http.StatusUnprocessableEntity,
+			},
+		)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/auth"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+)
+
+// fakeAttemptLogic serves fixed question, methods not used by test panic on
+// nil embedded interface.
+type fakeAttemptLogic struct {
+	service.AttemptLogic
+	next *model.Question
+}
+
+func (logic *fakeAttemptLogic) Next(ctx context.Context, actor *model.Actor, attemptID int) (*model.Question, error) {
+	return logic.next, nil
+}
+
+func TestAttemptHandlerNextHidesAnswerKey(t *testing.T) {
+	first, second := 1, 2
+	tolerance := 0.5
+	tests := []struct {
+		name        string
+		question    *model.Question
+		wantAnswers int
+	}{
+		{
+			name: "single choice",
+			question: &model.Question{ID: 7, Type: model.SingleChoice, Answers: []model.Answer{
+				{ID: 1, Text: "Paris", IsCorrect: true},
+				{ID: 2, Text: "Lyon"},
+			}},
+			wantAnswers: 2,
+		},
+		{
+			name: "ordering",
+			question: &model.Question{ID: 8, Type: model.Ordering, Answers: []model.Answer{
+				{ID: 3, Text: "first", Position: &first},
+				{ID: 4, Text: "second", Position: &second},
+			}},
+			wantAnswers: 2,
+		},
+		{
+			name: "free text",
+			question: &model.Question{ID: 9, Type: model.FreeText, Answers: []model.Answer{
+				{ID: 5, Text: "New York", IsCorrect: true},
+			}},
+			wantAnswers: 0,
+		},
+		{
+			name: "numeric",
+			question: &model.Question{ID: 10, Type: model.Numeric, Tolerance: &tolerance, Answers: []model.Answer{
+				{ID: 6, Text: "100", IsCorrect: true},
+			}},
+			wantAnswers: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAttemptHandler(&fakeAttemptLogic{next: tt.question})
+			r := httptest.NewRequest(http.MethodPost, "/attempts/1/next", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": "1"})
+			r = r.WithContext(auth.WithActor(r.Context(), &model.Actor{UserID: uuid.New(), Role: model.RoleEditor}))
+			w := httptest.NewRecorder()
+
+			handler.Next(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %v, want %v, body %s", w.Code, http.StatusOK, w.Body)
+			}
+			var body struct {
+				Answers []map[string]any `json:"answers"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unmarshal body: %v", err)
+			}
+			if len(body.Answers) != tt.wantAnswers {
+				t.Fatalf("got %v answers, want %v: %s", len(body.Answers), tt.wantAnswers, w.Body)
+			}
+			for _, answer := range body.Answers {
+				for _, key := range []string{"is_correct", "position"} {
+					if _, ok := answer[key]; ok {
+						t.Errorf("answer %v exposes %q: %s", answer["id"], key, w.Body)
+					}
+				}
+			}
+		})
+	}
+}
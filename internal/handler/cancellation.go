@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+// StatusClientClosedRequest is nginx's non-standard status of request
+// abandoned by client before response was ready.
+const StatusClientClosedRequest = 499
+
+// isCanceled reports whether err is caused by client disconnect or by query
+// timeout. Postgres may answer cancelled query with its own error, so request
+// context is checked too.
+func isCanceled(r *http.Request, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		util.CheckQueryCanceledErr(err) ||
+		r.Context().Err() != nil
+}
+
+// sendCanceled responds 504 when query ran out of time and 499 when client
+// went away.
+func sendCanceled(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	err error,
+) {
+	statusCode := http.StatusGatewayTimeout
+	errorMsg := "Request took too long, try again later"
+	if errors.Is(r.Context().Err(), context.Canceled) ||
+		(r.Context().Err() == nil && errors.Is(err, context.Canceled)) {
+		statusCode = StatusClientClosedRequest
+		errorMsg = "Request was cancelled by client"
+	}
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg:    errorMsg,
+			Error:       err,
+			StatusCode:  statusCode,
+		},
+	)
+}
@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	common.L = zap.NewNop()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type HealthEndpoints interface {
+	Live(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Ready(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type HealthHandler struct {
+	HealthSrv service.HealthLogic
+}
+
+func NewHealthHandler(healthSrv service.HealthLogic) *HealthHandler {
+	res := new(HealthHandler)
+	res.HealthSrv = healthSrv
+	return res
+}
+
+func (res *HealthHandler) Live(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.HealthHandler.Live"
+	res.sendHealth(w, r, op, res.HealthSrv.Live(r.Context()))
+}
+
+func (res *HealthHandler) Ready(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.HealthHandler.Ready"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	health := res.HealthSrv.Ready(r.Context())
+	if health.Status != model.HealthStatusOk {
+		failed := make([]string, 0, len(health.Checks))
+		for _, check := range health.Checks {
+			if check.Status != model.HealthStatusOk {
+				failed = append(failed, fmt.Sprintf("%v: %v", check.Name, check.Error))
+			}
+		}
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Service is not ready, " + strings.Join(failed, "; "),
+				Error:       fmt.Errorf("%v readiness checks failed", len(failed)),
+				StatusCode:  http.StatusServiceUnavailable,
+			},
+		)
+		return
+	}
+	res.sendHealth(w, r, op, health)
+}
+
+func (res *HealthHandler) sendHealth(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	health *model.Health,
+) {
+	jsonHealth, err := json.Marshal(health)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Health'",
+				Error:       err,
+				StatusCode:  http.StatusInternalServerError,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonHealth,
+		ResultMsg:   fmt.Sprintf("Health is '%v'", health.Status),
+		StatusCode:  http.StatusOK,
+	})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mbilarusdev/quiz/internal/auth"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+// authenticatedActor returns actor from bearer token, without it request is
+// answered with 401 and false is returned.
+func authenticatedActor(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+) (*model.Actor, bool) {
+	actor, ok := auth.ActorFrom(r.Context())
+	if !ok {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Request is not authenticated",
+				Error:       fmt.Errorf("no user in request context"),
+				StatusCode:  http.StatusUnauthorized,
+			},
+		)
+		return nil, false
+	}
+	return actor, true
+}
+
+// sendForbidden responds that actor is not allowed to perform action.
+func sendForbidden(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	forbiddenErr *service_errors.ForbiddenError,
+) {
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg:    fmt.Sprintf("Role '%v' is not allowed to %v", forbiddenErr.Role, forbiddenErr.Action),
+			Error:       forbiddenErr,
+			StatusCode:  http.StatusForbidden,
+		},
+	)
+}
+
+// sendUnknownUser responds that authenticated user must have account before
+// it posts anything referencing it.
+func sendUnknownUser(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	unknownUserErr *service_errors.UnknownUserError,
+) {
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg:    fmt.Sprintf("'User' with id=%v has no account, register it first", unknownUserErr.UserID),
+			Error:       unknownUserErr,
+			StatusCode:  http.StatusForbidden,
+		},
+	)
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type LeaderboardEndpoints interface {
+	Find(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type LeaderboardHandler struct {
+	LeaderboardSrv service.LeaderboardLogic
+}
+
+func NewLeaderboardHandler(leaderboardSrv service.LeaderboardLogic) *LeaderboardHandler {
+	res := new(LeaderboardHandler)
+	res.LeaderboardSrv = leaderboardSrv
+	return res
+}
+
+func (res *LeaderboardHandler) Find(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.LeaderboardHandler.Find"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	params := r.URL.Query()
+	query := &model.LeaderboardQuery{Window: params.Get("window")}
+	if rawQuizID := params.Get("quiz_id"); rawQuizID != "" {
+		quizID, err := strconv.Atoi(rawQuizID)
+		if err != nil {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to parse query parameter 'quiz_id'",
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+			return
+		}
+		query.QuizID = &quizID
+	}
+	if rawLimit := params.Get("limit"); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to parse query parameter 'limit'",
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+			return
+		}
+		query.Limit = limit
+	}
+	// Rank of "me" is rank of caller, API keys without user have none
+	if actor.UserID != uuid.Nil {
+		query.UserID = &actor.UserID
+	}
+	leaderboard, err := res.LeaderboardSrv.Find(r.Context(), query)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if notFoundErr, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Quiz' with id=%v not found!", notFoundErr.ID),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to find leaderboard, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonLeaderboard, err := json.Marshal(leaderboard)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Leaderboard'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonLeaderboard,
+		ResultMsg: fmt.Sprintf(
+			"'Leaderboard' for window '%v' finded succesfully",
+			leaderboard.Window,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
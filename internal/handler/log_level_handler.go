@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type LogLevelEndpoints interface {
+	Find(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Update(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type LogLevelHandler struct {
+	LogLevelSrv service.LogLevelLogic
+}
+
+func NewLogLevelHandler(logLevelSrv service.LogLevelLogic) *LogLevelHandler {
+	res := new(LogLevelHandler)
+	res.LogLevelSrv = logLevelSrv
+	return res
+}
+
+func (res *LogLevelHandler) Find(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.LogLevelHandler.Find"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	level, err := res.LogLevelSrv.Find(r.Context(), actor)
+	if err != nil {
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to find log level, some error occured",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+	res.sendLevel(w, r, op, level, "'LogLevel' finded succesfully")
+}
+
+func (res *LogLevelHandler) Update(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.LogLevelHandler.Update"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	level := &model.LogLevel{}
+	err = json.Unmarshal(bodyBytes, level)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'LogLevel' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	newLevel, err := res.LogLevelSrv.Update(r.Context(), actor, level)
+	if err != nil {
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to update log level, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	res.sendLevel(w, r, op, newLevel, fmt.Sprintf("'LogLevel' changed to '%v' succesfully", newLevel.Level))
+}
+
+func (res *LogLevelHandler) sendLevel(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	level *model.LogLevel,
+	resultMsg string,
+) {
+	jsonLevel, err := json.Marshal(level)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'LogLevel'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonLevel,
+		ResultMsg:   resultMsg,
+		StatusCode:  http.StatusOK,
+	})
+}
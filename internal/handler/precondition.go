@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+// ifMatchVersion reads version required by If-Match header, on missing or
+// malformed header it sends error response and returns false.
+func ifMatchVersion(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+) (int, bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Header 'If-Match' with entity version is required",
+				Error:       fmt.Errorf("missing If-Match header"),
+				StatusCode:  http.StatusPreconditionRequired,
+			},
+		)
+		return 0, false
+	}
+	version, err := util.ParseIfMatch(header)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse header 'If-Match'",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return 0, false
+	}
+	return version, true
+}
+
+// sendConflict responds that precondition failed and exposes current version.
+func sendConflict(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	conflictErr *service_errors.ConflictError,
+) {
+	w.Header().Set("ETag", util.ETag(conflictErr.Version))
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg: fmt.Sprintf(
+				"'%v' with id=%v was modified, current version is %v",
+				conflictErr.Entity,
+				conflictErr.ID,
+				conflictErr.Version,
+			),
+			Error:      conflictErr,
+			StatusCode: http.StatusPreconditionFailed,
+		},
+	)
+}
@@ -31,6 +31,26 @@ type QuestionEndpoints interface {
 		w http.ResponseWriter,
 		r *http.Request,
 	)
+	Update(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Patch(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindTrash(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Restore(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Submit(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
 }
 
 type QuestionHandler struct {
@@ -60,6 +80,10 @@ func (res *QuestionHandler) Create(
 			)
 		}
 	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
 		util.SendError(
@@ -89,8 +113,20 @@ func (res *QuestionHandler) Create(
 		)
 		return
 	}
-	newQuestion, err := res.QuestionSrv.Create(question)
+	newQuestion, err := res.QuestionSrv.Create(r.Context(), actor, question)
 	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if unknownUserErr, ok := err.(*service_errors.UnknownUserError); ok {
+			sendUnknownUser(w, r, op, unknownUserErr)
+			return
+		}
 		if _, ok := err.(*service_errors.DuplicateError); ok {
 			util.SendError(
 				model.SendError{
@@ -105,6 +141,17 @@ func (res *QuestionHandler) Create(
 					StatusCode: http.StatusUnprocessableEntity,
 				},
 			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
 		} else {
 			util.SendError(
 				model.SendError{
@@ -165,6 +212,10 @@ func (res *QuestionHandler) FindOneDetailed(
 			)
 		}
 	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
@@ -180,8 +231,16 @@ func (res *QuestionHandler) FindOneDetailed(
 		)
 		return
 	}
-	question, err := res.QuestionSrv.FindOneDetailed(id)
+	question, err := res.QuestionSrv.FindOneDetailed(r.Context(), actor, id)
 	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
 		if _, ok := err.(*service_errors.NotFoundError); ok {
 			util.SendError(
 				model.SendError{
@@ -213,6 +272,22 @@ func (res *QuestionHandler) FindOneDetailed(
 		}
 		return
 	}
+	etag := util.ETag(question.Version)
+	w.Header().Set("ETag", etag)
+	if util.IfNoneMatch(r.Header.Get("If-None-Match"), etag) {
+		util.SendSuccess(model.SendSuccess{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			Bytes:       make([]byte, 0),
+			ResultMsg: fmt.Sprintf(
+				"'Question' with id=%v not modified",
+				id,
+			),
+			StatusCode: http.StatusNotModified,
+		})
+		return
+	}
 	jsonQuestion, err := json.Marshal(question)
 	if err != nil {
 		util.SendError(
@@ -258,28 +333,67 @@ func (res *QuestionHandler) FindAll(
 			)
 		}
 	}()
-	questions, err := res.QuestionSrv.FindAll()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	params, err := util.ParseListParams(r.URL.Query())
 	if err != nil {
 		util.SendError(
 			model.SendError{
 				W:           w,
 				R:           r,
 				HandlerName: op,
-				ErrorMsg:    "Failed to find all questions, some error occured",
+				ErrorMsg:    fmt.Sprintf("Failed to parse %v", err.Error()),
 				Error:       err,
-				StatusCode:  http.StatusUnprocessableEntity,
+				StatusCode:  http.StatusBadRequest,
 			},
 		)
 		return
 	}
-	jsonQuestions, err := json.Marshal(questions)
+	page, err := res.QuestionSrv.FindAll(r.Context(), actor, params)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to find all questions, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestions, err := json.Marshal(page)
 	if err != nil {
 		util.SendError(
 			model.SendError{
 				W:           w,
 				R:           r,
 				HandlerName: op,
-				ErrorMsg:    "Failed to marshal response with list of 'Question'",
+				ErrorMsg:    "Failed to marshal response with page of 'Question'",
 				Error:       err,
 				StatusCode:  http.StatusUnprocessableEntity,
 			},
@@ -292,8 +406,12 @@ func (res *QuestionHandler) FindAll(
 		R:           r,
 		HandlerName: op,
 		Bytes:       jsonQuestions,
-		ResultMsg:   "List of 'Question' finded succesfully",
-		StatusCode:  http.StatusOK,
+		ResultMsg: fmt.Sprintf(
+			"Page of %v 'Question' from %v finded succesfully",
+			len(page.Items),
+			page.Total,
+		),
+		StatusCode: http.StatusOK,
 	})
 }
 
@@ -314,6 +432,10 @@ func (res *QuestionHandler) Delete(
 			)
 		}
 	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
@@ -329,8 +451,46 @@ func (res *QuestionHandler) Delete(
 		)
 		return
 	}
-	deleted, err := res.QuestionSrv.Delete(id)
+	hard := false
+	if value := r.URL.Query().Get("hard"); value != "" {
+		hard, err = strconv.ParseBool(value)
+		if err != nil {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to parse query parameter 'hard'",
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+			return
+		}
+	}
+	version, ok := ifMatchVersion(w, r, op)
+	if !ok {
+		return
+	}
+	var deleted bool
+	if hard {
+		deleted, err = res.QuestionSrv.Purge(r.Context(), actor, id, version)
+	} else {
+		deleted, err = res.QuestionSrv.Delete(r.Context(), actor, id, version)
+	}
 	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if conflictErr, ok := err.(*service_errors.ConflictError); ok {
+			sendConflict(w, r, op, conflictErr)
+			return
+		}
 		util.SendError(
 			model.SendError{
 				W:           w,
@@ -372,3 +532,683 @@ func (res *QuestionHandler) Delete(
 		StatusCode: http.StatusNoContent,
 	})
 }
+
+func (res *QuestionHandler) Submit(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuestionHandler.Submit"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	submission := &model.Submission{}
+	err = json.Unmarshal(bodyBytes, submission)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Submission' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	submission.UserID = actor.UserID
+	result, err := res.QuestionSrv.Grade(r.Context(), actor, id, submission)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"'Question' with id=%v not found",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusNotFound,
+				},
+			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to grade submission for 'Question' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'SubmissionResult'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonResult,
+		ResultMsg: fmt.Sprintf(
+			"Submission for 'Question' with id=%v graded succesfully, correct=%v",
+			id,
+			result.Correct,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuestionHandler) Update(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuestionHandler.Update"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	version, ok := ifMatchVersion(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	question := &model.Question{}
+	err = json.Unmarshal(bodyBytes, question)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Question' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	updatedQuestion, err := res.QuestionSrv.Update(r.Context(), actor, id, version, question)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Question' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if conflictErr, ok := err.(*service_errors.ConflictError); ok {
+			sendConflict(w, r, op, conflictErr)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to update 'Question' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestion, err := json.Marshal(updatedQuestion)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	w.Header().Set("ETag", util.ETag(updatedQuestion.Version))
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestion,
+		ResultMsg: fmt.Sprintf(
+			"'Question' with id=%v updated succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuestionHandler) Patch(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuestionHandler.Patch"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	version, ok := ifMatchVersion(w, r, op)
+	if !ok {
+		return
+	}
+	if !util.IsMergePatch(r.Header.Get("Content-Type")) {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Content-Type must be '%v' or 'application/json'",
+					util.MergePatchContentType,
+				),
+				Error:      fmt.Errorf("unsupported Content-Type %q", r.Header.Get("Content-Type")),
+				StatusCode: http.StatusUnsupportedMediaType,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	updatedQuestion, err := res.QuestionSrv.Patch(r.Context(), actor, id, version, bodyBytes)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Question' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if conflictErr, ok := err.(*service_errors.ConflictError); ok {
+			sendConflict(w, r, op, conflictErr)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to update 'Question' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestion, err := json.Marshal(updatedQuestion)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	w.Header().Set("ETag", util.ETag(updatedQuestion.Version))
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestion,
+		ResultMsg: fmt.Sprintf(
+			"'Question' with id=%v updated succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuestionHandler) FindTrash(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuestionHandler.FindTrash"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	params, err := util.ParseListParams(r.URL.Query())
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("Failed to parse %v", err.Error()),
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	page, err := res.QuestionSrv.FindTrash(r.Context(), actor, params)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to find deleted questions, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestions, err := json.Marshal(page)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with page of deleted 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestions,
+		ResultMsg: fmt.Sprintf(
+			"Page of %v deleted 'Question' from %v finded succesfully",
+			len(page.Items),
+			page.Total,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuestionHandler) Restore(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuestionHandler.Restore"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	withAnswers := false
+	if value := r.URL.Query().Get("with_answers"); value != "" {
+		withAnswers, err = strconv.ParseBool(value)
+		if err != nil {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to parse query parameter 'with_answers'",
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+			return
+		}
+	}
+	restoredQuestion, err := res.QuestionSrv.Restore(r.Context(), actor, id, withAnswers)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("Deleted 'Question' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if stateErr, ok := err.(*service_errors.StateError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to restore 'Question' with id=%v, because it is %v",
+						id,
+						stateErr.State,
+					),
+					Error:      err,
+					StatusCode: http.StatusConflict,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to restore 'Question' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestion, err := json.Marshal(restoredQuestion)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	w.Header().Set("ETag", util.ETag(restoredQuestion.Version))
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestion,
+		ResultMsg: fmt.Sprintf(
+			"'Question' with id=%v restored succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/auth"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	"github.com/mbilarusdev/quiz/internal/service"
+	"gorm.io/gorm"
+)
+
+// fakeQuestionProvider serves fixed question, methods not used by test panic
+// on nil embedded interface.
+type fakeQuestionProvider struct {
+	repository.QuestionProvider
+	question *model.Question
+}
+
+func (provider *fakeQuestionProvider) GetOne(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionID int,
+	withAnswers bool,
+) (*model.Question, error) {
+	question := *provider.question
+	question.Answers = append([]model.Answer(nil), provider.question.Answers...)
+	return &question, nil
+}
+
+func newQuestionHandlerOf(question *model.Question) *QuestionHandler {
+	return NewQuestionHandler(service.NewQuestionService(&fakeQuestionProvider{question: question}, nil, nil))
+}
+
+func requestAs(r *http.Request, role string) *http.Request {
+	r = mux.SetURLVars(r, map[string]string{"id": "1"})
+	return r.WithContext(auth.WithActor(r.Context(), &model.Actor{UserID: uuid.New(), Role: role}))
+}
+
+func TestQuestionHandlerSubmitHidesCorrectAnswerIDs(t *testing.T) {
+	question := &model.Question{ID: 1, Type: model.SingleChoice, Answers: []model.Answer{
+		{ID: 1, Text: "Paris", IsCorrect: true},
+		{ID: 2, Text: "Lyon"},
+	}}
+	tests := []struct {
+		role    string
+		wantKey bool
+	}{
+		{role: model.RolePlayer},
+		{role: model.RoleEditor, wantKey: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			handler := newQuestionHandlerOf(question)
+			r := requestAs(httptest.NewRequest(http.MethodPost, "/questions/1/submissions", strings.NewReader(`{"answer_id":2}`)), tt.role)
+			w := httptest.NewRecorder()
+
+			handler.Submit(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %v, want %v, body %s", w.Code, http.StatusOK, w.Body)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unmarshal body: %v", err)
+			}
+			if body["correct"] != false {
+				t.Errorf("correct = %v, want false", body["correct"])
+			}
+			if _, ok := body["correct_answer_ids"]; ok != tt.wantKey {
+				t.Errorf("correct_answer_ids present = %v, want %v: %s", ok, tt.wantKey, w.Body)
+			}
+		})
+	}
+}
+
+func TestQuestionHandlerFindOneDetailedHidesKeyInText(t *testing.T) {
+	tolerance := 0.5
+	tests := []struct {
+		name        string
+		role        string
+		question    *model.Question
+		wantAnswers int
+	}{
+		{
+			name:        "free text to player",
+			role:        model.RolePlayer,
+			question:    &model.Question{ID: 1, Type: model.FreeText, Answers: []model.Answer{{ID: 1, Text: "New York", IsCorrect: true}}},
+			wantAnswers: 0,
+		},
+		{
+			name:        "numeric to player",
+			role:        model.RolePlayer,
+			question:    &model.Question{ID: 1, Type: model.Numeric, Tolerance: &tolerance, Answers: []model.Answer{{ID: 1, Text: "100", IsCorrect: true}}},
+			wantAnswers: 0,
+		},
+		{
+			name:        "free text to editor",
+			role:        model.RoleEditor,
+			question:    &model.Question{ID: 1, Type: model.FreeText, Answers: []model.Answer{{ID: 1, Text: "New York", IsCorrect: true}}},
+			wantAnswers: 1,
+		},
+		{
+			name: "single choice to player",
+			role: model.RolePlayer,
+			question: &model.Question{ID: 1, Type: model.SingleChoice, Answers: []model.Answer{
+				{ID: 1, Text: "Paris", IsCorrect: true},
+				{ID: 2, Text: "Lyon"},
+			}},
+			wantAnswers: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newQuestionHandlerOf(tt.question)
+			r := requestAs(httptest.NewRequest(http.MethodGet, "/questions/1", nil), tt.role)
+			w := httptest.NewRecorder()
+
+			handler.FindOneDetailed(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %v, want %v, body %s", w.Code, http.StatusOK, w.Body)
+			}
+			var body struct {
+				Answers []map[string]any `json:"answers"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unmarshal body: %v", err)
+			}
+			if len(body.Answers) != tt.wantAnswers {
+				t.Errorf("got %v answers, want %v: %s", len(body.Answers), tt.wantAnswers, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,831 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type QuizEndpoints interface {
+	Create(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindOne(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindAll(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Update(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Delete(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindQuestions(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	SetQuestions(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type QuizHandler struct {
+	QuizSrv service.QuizLogic
+}
+
+func NewQuizHandler(quizSrv service.QuizLogic) *QuizHandler {
+	res := new(QuizHandler)
+	res.QuizSrv = quizSrv
+	return res
+}
+
+func (res *QuizHandler) Create(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.Create"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	quiz := &model.Quiz{}
+	err = json.Unmarshal(bodyBytes, quiz)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Quiz' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	newQuiz, err := res.QuizSrv.Create(r.Context(), actor, quiz)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if notFoundErr, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to create quiz, but 'Question' with id=%v not found",
+						notFoundErr.ID,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else if _, ok := err.(*service_errors.DuplicateError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to create quiz, but 'Quiz' with id=%v already exist",
+						quiz.ID,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to create quiz, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuiz, err := json.Marshal(newQuiz)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Quiz'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuiz,
+		ResultMsg: fmt.Sprintf(
+			"'Quiz' with id=%v created succesfully",
+			newQuiz.ID,
+		),
+		StatusCode: http.StatusCreated,
+	})
+}
+
+func (res *QuizHandler) FindOne(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.FindOne"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	quiz, err := res.QuizSrv.FindOne(r.Context(), id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Quiz' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to find 'Quiz' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuiz, err := json.Marshal(quiz)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Quiz'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuiz,
+		ResultMsg: fmt.Sprintf(
+			"'Quiz' with id=%v and his questions finded succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuizHandler) FindAll(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.FindAll"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	quizzes, err := res.QuizSrv.FindAll(r.Context())
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to find all quizzes, some error occured",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+	jsonQuizzes, err := json.Marshal(quizzes)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with list of 'Quiz'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuizzes,
+		ResultMsg:   "List of 'Quiz' finded succesfully",
+		StatusCode:  http.StatusOK,
+	})
+}
+
+func (res *QuizHandler) Update(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.Update"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	quiz := &model.Quiz{}
+	err = json.Unmarshal(bodyBytes, quiz)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Quiz' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	updatedQuiz, err := res.QuizSrv.Update(r.Context(), actor, id, quiz)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Quiz' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to update 'Quiz' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuiz, err := json.Marshal(updatedQuiz)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Quiz'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuiz,
+		ResultMsg: fmt.Sprintf(
+			"'Quiz' with id=%v updated succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuizHandler) Delete(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.Delete"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	deleted, err := res.QuizSrv.Delete(r.Context(), actor, id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg: fmt.Sprintf(
+					"Failed to delete 'Quiz' with id=%v, some error occured",
+					id,
+				),
+				Error:      err,
+				StatusCode: http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+	if !deleted {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    fmt.Sprintf("'Quiz' with id=%v not found!", id),
+				Error:       &service_errors.NotFoundError{ID: id, Entity: "Quiz"},
+				StatusCode:  http.StatusNotFound,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       make([]byte, 0),
+		ResultMsg: fmt.Sprintf(
+			"'Quiz' with id=%v deleted succesfully",
+			id,
+		),
+		StatusCode: http.StatusNoContent,
+	})
+}
+
+func (res *QuizHandler) FindQuestions(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.FindQuestions"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	questions, err := res.QuizSrv.FindQuestions(r.Context(), id)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if _, ok := err.(*service_errors.NotFoundError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Quiz' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to find questions of 'Quiz' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestions, err := json.Marshal(questions)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with list of 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestions,
+		ResultMsg: fmt.Sprintf(
+			"List of 'Question' of 'Quiz' with id=%v finded succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
+
+func (res *QuizHandler) SetQuestions(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.QuizHandler.SetQuestions"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to parse path parameter {id}",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	order := &model.QuizQuestionsOrder{}
+	err = json.Unmarshal(bodyBytes, order)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'QuizQuestionsOrder' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	questions, err := res.QuizSrv.SetQuestions(r.Context(), actor, id, order.QuestionIDs)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+			sendForbidden(w, r, op, forbiddenErr)
+			return
+		}
+		if notFoundErr, ok := err.(*service_errors.NotFoundError); ok && notFoundErr.Entity == "Quiz" {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    fmt.Sprintf("'Quiz' with id=%v not found!", id),
+					Error:       err,
+					StatusCode:  http.StatusNotFound,
+				},
+			)
+		} else if ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to set questions of quiz, but 'Question' with id=%v not found",
+						notFoundErr.ID,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		} else if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg: fmt.Sprintf(
+						"Failed to set questions of 'Quiz' with id=%v, some error occured",
+						id,
+					),
+					Error:      err,
+					StatusCode: http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonQuestions, err := json.Marshal(questions)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with list of 'Question'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonQuestions,
+		ResultMsg: fmt.Sprintf(
+			"Questions of 'Quiz' with id=%v set succesfully",
+			id,
+		),
+		StatusCode: http.StatusOK,
+	})
+}
@@ -0,0 +1,502 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/service"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+type UserEndpoints interface {
+	Register(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	Login(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	FindMe(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	UpdateMe(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+	ChangePassword(
+		w http.ResponseWriter,
+		r *http.Request,
+	)
+}
+
+type UserHandler struct {
+	UserSrv service.UserLogic
+}
+
+func NewUserHandler(userSrv service.UserLogic) *UserHandler {
+	res := new(UserHandler)
+	res.UserSrv = userSrv
+	return res
+}
+
+func (res *UserHandler) Register(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.UserHandler.Register"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	registration := &model.Registration{}
+	err = json.Unmarshal(bodyBytes, registration)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Registration' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	user, err := res.UserSrv.Register(r.Context(), registration)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if validationErr, ok := err.(*service_errors.ValidationError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    validationErr.Reason,
+					Error:       err,
+					StatusCode:  http.StatusBadRequest,
+				},
+			)
+		} else if _, ok := err.(*service_errors.DuplicateError); ok {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "'User' with this email already exist",
+					Error:       err,
+					StatusCode:  http.StatusConflict,
+				},
+			)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to register user, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonUser, err := json.Marshal(user)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'User'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonUser,
+		ResultMsg:   "'User' registered succesfully",
+		StatusCode:  http.StatusCreated,
+	})
+}
+
+func (res *UserHandler) Login(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.UserHandler.Login"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	credentials := &model.Credentials{}
+	err = json.Unmarshal(bodyBytes, credentials)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'Credentials' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	session, err := res.UserSrv.Login(r.Context(), credentials)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		if _, ok := err.(*service_errors.CredentialsError); ok {
+			sendInvalidCredentials(w, r, op, err)
+		} else {
+			util.SendError(
+				model.SendError{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					ErrorMsg:    "Failed to login, some error occured",
+					Error:       err,
+					StatusCode:  http.StatusUnprocessableEntity,
+				},
+			)
+		}
+		return
+	}
+	jsonSession, err := json.Marshal(session)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'Session'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonSession,
+		ResultMsg:   "'Session' issued succesfully",
+		StatusCode:  http.StatusOK,
+	})
+}
+
+func (res *UserHandler) FindMe(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.UserHandler.FindMe"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	user, err := res.UserSrv.FindMe(r.Context(), actor)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendUserError(w, r, op, err, "Failed to find user, some error occured")
+		return
+	}
+	res.sendUser(w, r, op, user, "'User' finded succesfully")
+}
+
+func (res *UserHandler) UpdateMe(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.UserHandler.UpdateMe"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	profile := &model.User{}
+	err = json.Unmarshal(bodyBytes, profile)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'User' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	user, err := res.UserSrv.UpdateMe(r.Context(), actor, profile)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendUserError(w, r, op, err, "Failed to update user, some error occured")
+		return
+	}
+	res.sendUser(w, r, op, user, "'User' updated succesfully")
+}
+
+func (res *UserHandler) ChangePassword(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	op := "handler.UserHandler.ChangePassword"
+	defer func() {
+		if p := recover(); p != nil {
+			util.SendFatal(
+				model.SendFatal{
+					W:           w,
+					R:           r,
+					HandlerName: op,
+					Panic:       p,
+				},
+			)
+		}
+	}()
+	actor, ok := authenticatedActor(w, r, op)
+	if !ok {
+		return
+	}
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to read body bytes",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	change := &model.PasswordChange{}
+	err = json.Unmarshal(bodyBytes, change)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed unmarshal bytes to 'PasswordChange' model",
+				Error:       err,
+				StatusCode:  http.StatusBadRequest,
+			},
+		)
+		return
+	}
+	err = res.UserSrv.ChangePassword(r.Context(), actor, change)
+	if err != nil {
+		if isCanceled(r, err) {
+			sendCanceled(w, r, op, err)
+			return
+		}
+		sendUserError(w, r, op, err, "Failed to change password, some error occured")
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       make([]byte, 0),
+		ResultMsg:   "Password of 'User' changed succesfully",
+		StatusCode:  http.StatusNoContent,
+	})
+}
+
+func (res *UserHandler) sendUser(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	user *model.User,
+	resultMsg string,
+) {
+	jsonUser, err := json.Marshal(user)
+	if err != nil {
+		util.SendError(
+			model.SendError{
+				W:           w,
+				R:           r,
+				HandlerName: op,
+				ErrorMsg:    "Failed to marshal response with 'User'",
+				Error:       err,
+				StatusCode:  http.StatusUnprocessableEntity,
+			},
+		)
+		return
+	}
+
+	util.SendSuccess(model.SendSuccess{
+		W:           w,
+		R:           r,
+		HandlerName: op,
+		Bytes:       jsonUser,
+		ResultMsg:   resultMsg,
+		StatusCode:  http.StatusOK,
+	})
+}
+
+// sendUserError maps errors of profile endpoints to responses.
+func sendUserError(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	err error,
+	errorMsg string,
+) {
+	if forbiddenErr, ok := err.(*service_errors.ForbiddenError); ok {
+		sendForbidden(w, r, op, forbiddenErr)
+		return
+	}
+	if _, ok := err.(*service_errors.CredentialsError); ok {
+		sendInvalidCredentials(w, r, op, err)
+		return
+	}
+	statusCode := http.StatusUnprocessableEntity
+	if validationErr, ok := err.(*service_errors.ValidationError); ok {
+		errorMsg = validationErr.Reason
+		statusCode = http.StatusBadRequest
+	} else if _, ok := err.(*service_errors.NotFoundError); ok {
+		errorMsg = "'User' of authenticated actor not found!"
+		statusCode = http.StatusNotFound
+	}
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg:    errorMsg,
+			Error:       err,
+			StatusCode:  statusCode,
+		},
+	)
+}
+
+func sendInvalidCredentials(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	err error,
+) {
+	util.SendError(
+		model.SendError{
+			W:           w,
+			R:           r,
+			HandlerName: op,
+			ErrorMsg:    "Invalid email or password",
+			Error:       err,
+			StatusCode:  http.StatusUnauthorized,
+		},
+	)
+}
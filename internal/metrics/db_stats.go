@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterDB exposes connection pool stats of db, it may be called again with
+// new pool, e.g. after app restart, then previous pool is replaced.
+func RegisterDB(db *sql.DB, name string) error {
+	collector := collectors.NewDBStatsCollector(db, name)
+	if err := Registry.Register(collector); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			return err
+		}
+		Registry.Unregister(collector)
+		return Registry.Register(collector)
+	}
+	return nil
+}
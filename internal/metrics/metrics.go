@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "quiz"
+
+// Registry keeps every collector of app, it is exposed by Handler.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, method and status.",
+	}, []string{"route", "method", "status"})
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route template, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Latency of database queries by operation, table and result.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table", "result"})
+
+	QuestionsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "questions_created_total",
+		Help:      "Questions created.",
+	})
+	AnswersSubmitted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "answers_submitted_total",
+		Help:      "Graded answers by source: standalone submission or quiz attempt.",
+	}, []string{"source"})
+	Gradings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gradings_total",
+		Help:      "Grading outcomes by question type.",
+	}, []string{"type", "outcome"})
+)
+
+// Sources of submitted answers.
+const (
+	SourceSubmission = "submission"
+	SourceAttempt    = "attempt"
+)
+
+// Outcomes of grading.
+const (
+	OutcomeCorrect   = "correct"
+	OutcomePartial   = "partial"
+	OutcomeIncorrect = "incorrect"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		DBQueryDuration,
+		QuestionsCreated,
+		AnswersSubmitted,
+		Gradings,
+	)
+}
+
+// Handler serves metrics of Registry in Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveGrade counts graded answer of source and its outcome.
+func ObserveGrade(source string, questionType string, correct bool, score float64) {
+	outcome := OutcomeIncorrect
+	if correct {
+		outcome = OutcomeCorrect
+	} else if score > 0 {
+		outcome = OutcomePartial
+	}
+	AnswersSubmitted.WithLabelValues(source).Inc()
+	Gradings.WithLabelValues(questionType, outcome).Inc()
+}
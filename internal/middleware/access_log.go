@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"go.uber.org/zap"
+)
+
+// AccessLog writes one log line per request with status and latency, it must
+// be wrapped by RequestID to get request_id field.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		common.Log(r.Context()).Info("Access",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", recorder.status),
+			zap.Duration("latency", time.Since(started)),
+			zap.Int("bytes", recorder.bytes),
+			zap.String("remote", r.RemoteAddr),
+			zap.String("user_agent", r.UserAgent()))
+	})
+}
+
+// statusRecorder remembers status and size of response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.status = status
+		recorder.wroteHeader = true
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(body []byte) (int, error) {
+	recorder.wroteHeader = true
+	n, err := recorder.ResponseWriter.Write(body)
+	recorder.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach underlying writer.
+func (recorder *statusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
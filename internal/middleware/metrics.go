@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/metrics"
+)
+
+// unmatchedRoute labels requests which match no route, so unknown paths
+// don't blow up cardinality of metrics.
+const unmatchedRoute = "unmatched"
+
+// Metrics counts requests and observes their latency labeled by route
+// template of router, e.g. /questions/{id}.
+func Metrics(router *mux.Router) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			route := unmatchedRoute
+			var match mux.RouteMatch
+			if router.Match(r, &match) && match.MatchErr == nil && match.Route != nil {
+				if template, err := match.Route.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			status := strconv.Itoa(recorder.status)
+			metrics.HTTPRequests.WithLabelValues(route, r.Method, status).Inc()
+			metrics.HTTPRequestDuration.
+				WithLabelValues(route, r.Method, status).
+				Observe(time.Since(started).Seconds())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/mbilarusdev/quiz/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/questions/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/questions", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}).Methods(http.MethodGet)
+	handler := Metrics(router)(router)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantRoute  string
+		wantStatus string
+	}{
+		{name: "route template instead of path", method: http.MethodGet, target: "/questions/17", wantRoute: "/questions/{id:[0-9]+}", wantStatus: "404"},
+		{name: "implicit ok status", method: http.MethodGet, target: "/questions?limit=5", wantRoute: "/questions", wantStatus: "200"},
+		{name: "unknown path", method: http.MethodGet, target: "/questions/abc/answers", wantRoute: unmatchedRoute, wantStatus: "404"},
+		{name: "method not allowed", method: http.MethodDelete, target: "/questions", wantRoute: unmatchedRoute, wantStatus: "405"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := metrics.HTTPRequests.WithLabelValues(tt.wantRoute, tt.method, tt.wantStatus)
+			before := testutil.ToFloat64(counter)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("requests with route %q, status %v increased by %v, want 1", tt.wantRoute, tt.wantStatus, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		propagate bool
+	}{
+		{name: "propagates client id", header: "client-id-42", propagate: true},
+		{name: "propagates id of max length", header: strings.Repeat("a", maxRequestIDLength), propagate: true},
+		{name: "generates missing id"},
+		{name: "replaces too long id", header: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "replaces id with spaces", header: "id with spaces"},
+		{name: "replaces id with control characters", header: "id\x1bforged"},
+		{name: "replaces non ascii id", header: "идентификатор"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				common.Log(r.Context()).Info("handled")
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+			if tt.header != "" {
+				r.Header.Set(HeaderRequestID, tt.header)
+			}
+			r = r.WithContext(common.WithLogger(r.Context(), zap.New(core)))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			seen := w.Header().Get(HeaderRequestID)
+			if tt.propagate {
+				if seen != tt.header {
+					t.Errorf("response %v = %q, want %q", HeaderRequestID, seen, tt.header)
+				}
+			} else if _, err := uuid.Parse(seen); err != nil {
+				t.Errorf("response %v = %q, want generated uuid", HeaderRequestID, seen)
+			}
+			entries := logs.All()
+			if len(entries) != 1 {
+				t.Fatalf("got %v log entries, want 1", len(entries))
+			}
+			if got := entries[0].ContextMap()["request_id"]; got != seen {
+				t.Errorf("logged request_id = %v, want %q", got, seen)
+			}
+		})
+	}
+}
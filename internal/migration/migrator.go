@@ -0,0 +1,103 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+const (
+	CommandUp     = "up"
+	CommandDown   = "down"
+	CommandStatus = "status"
+	CommandRedo   = "redo"
+)
+
+var Commands = []string{CommandUp, CommandDown, CommandStatus, CommandRedo}
+
+// Migrator applies goose migrations from fsys, applied versions are tracked
+// in table with the same name goose CLI uses. Concurrent migrators, e.g.
+// several replicas migrating on start, are serialized by advisory lock.
+type Migrator struct {
+	Provider *goose.Provider
+}
+
+func NewMigrator(db *sql.DB, fsys fs.FS, table string) (*Migrator, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := goose.NewProvider(
+		goose.DialectPostgres,
+		db,
+		fsys,
+		goose.WithTableName(table),
+		goose.WithSessionLocker(locker),
+	)
+	if err != nil {
+		return nil, err
+	}
+	migrator := new(Migrator)
+	migrator.Provider = provider
+	return migrator, nil
+}
+
+// Pending reports whether some migration is not applied yet.
+func (migrator *Migrator) Pending(ctx context.Context) (bool, error) {
+	return migrator.Provider.HasPending(ctx)
+}
+
+// Run executes goose command and writes its results to out.
+func (migrator *Migrator) Run(ctx context.Context, command string, out io.Writer) error {
+	switch command {
+	case CommandUp:
+		results, err := migrator.Provider.Up(ctx)
+		printResults(out, results...)
+		if err == nil && len(results) == 0 {
+			fmt.Fprintln(out, "no migrations to apply")
+		}
+		return err
+	case CommandDown:
+		result, err := migrator.Provider.Down(ctx)
+		printResults(out, result)
+		return err
+	case CommandRedo:
+		result, err := migrator.Provider.Down(ctx)
+		printResults(out, result)
+		if err != nil {
+			return err
+		}
+		result, err = migrator.Provider.UpByOne(ctx)
+		printResults(out, result)
+		return err
+	case CommandStatus:
+		statuses, err := migrator.Provider.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.State == goose.StateApplied {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%-19v %v\n", appliedAt, path.Base(status.Source.Path))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected one of up, down, status, redo", command)
+	}
+}
+
+func printResults(out io.Writer, results ...*goose.MigrationResult) {
+	for _, result := range results {
+		if result != nil {
+			fmt.Fprintln(out, result.String())
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	RolePlayer = "player"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+	// RoleAPIKey is role of actors authenticated with API key
+	RoleAPIKey = "api_key"
+)
+
+// Actor is authenticated user on whose behalf request is performed, actors
+// authenticated with API key are limited by scopes of the key instead of role.
+type Actor struct {
+	UserID   uuid.UUID
+	Role     string
+	APIKeyID int
+	Scopes   []string
+}
+
+func (a Actor) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("user_id", a.UserID.String())
+	enc.AddString("role", a.Role)
+	if a.APIKeyID != 0 {
+		enc.AddInt("api_key_id", a.APIKeyID)
+		enc.AddString("scopes", strings.Join(a.Scopes, ","))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,15 +14,48 @@ type Answer struct {
 	QuestionID int            `gorm:"index;not null"                json:"question_id"`
 	UserID     uuid.UUID      `gorm:"type:uuid;index;not null"      json:"user_id"`
 	Text       string         `gorm:"not null"                      json:"text"`
+	IsCorrect  bool           `gorm:"not null;default:false"        json:"is_correct"`
+	Position   *int           `gorm:"default:null"                  json:"position,omitempty"`
+	Version    int            `gorm:"not null;default:1"            json:"version"`
 	CreatedAt  time.Time      `gorm:"autoCreateTime"                json:"created_at,omitzero"`
 	UpdatedAt  time.Time      `gorm:"autoUpdateTime"                json:"updated_at,omitzero"`
 	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	// keyHidden omits is_correct and position from JSON, see HideKey
+	keyHidden bool
+}
+
+// answerJSON has fields of Answer without its methods
+type answerJSON Answer
+
+// HideKey clears is_correct and position and omits them from JSON, so answer
+// can be shown to actor who is not allowed to know the answer key.
+func (a *Answer) HideKey() {
+	a.IsCorrect = false
+	a.Position = nil
+	a.keyHidden = true
+}
+
+func (a Answer) MarshalJSON() ([]byte, error) {
+	if !a.keyHidden {
+		return json.Marshal(answerJSON(a))
+	}
+	return json.Marshal(struct {
+		answerJSON
+		IsCorrect *bool `json:"is_correct,omitempty"`
+		Position  *int  `json:"position,omitempty"`
+	}{answerJSON: answerJSON(a)})
 }
 
 func (a Answer) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("id", a.ID)
 	enc.AddInt("question_id", a.QuestionID)
 	enc.AddString("text", a.Text)
+	enc.AddBool("is_correct", a.IsCorrect)
+	enc.AddInt("version", a.Version)
+	if a.Position != nil {
+		enc.AddInt("position", *a.Position)
+	}
 	enc.AddTime("created_at", a.CreatedAt)
 	enc.AddTime("updated_at", a.UpdatedAt)
 	enc.AddTime("deleted_at", a.DeletedAt.Time)
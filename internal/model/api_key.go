@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	ScopeQuestionsRead  = "questions:read"
+	ScopeQuestionsWrite = "questions:write"
+	ScopeAnswersWrite   = "answers:write"
+	ScopeAdmin          = "admin"
+)
+
+type APIKey struct {
+	ID         int        `gorm:"primarykey"                          json:"id,omitempty"`
+	Name       string     `gorm:"not null"                            json:"name"`
+	Prefix     string     `gorm:"not null"                            json:"prefix"`
+	KeyHash    string     `gorm:"not null;uniqueIndex"                json:"-"`
+	UserID     uuid.UUID  `gorm:"type:uuid;index;not null"            json:"user_id"`
+	Scopes     []string   `gorm:"serializer:json;type:jsonb;not null" json:"scopes"`
+	ExpiresAt  *time.Time `gorm:"default:null"                        json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `gorm:"default:null"                        json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `gorm:"default:null"                        json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"                      json:"created_at,omitzero"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime"                      json:"updated_at,omitzero"`
+	Key        string     `gorm:"-"                                   json:"key,omitempty"`
+}
+
+func (k APIKey) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", k.ID)
+	enc.AddString("name", k.Name)
+	enc.AddString("prefix", k.Prefix)
+	enc.AddString("user_id", k.UserID.String())
+	enc.AddString("scopes", strings.Join(k.Scopes, ","))
+	if k.ExpiresAt != nil {
+		enc.AddTime("expires_at", *k.ExpiresAt)
+	}
+	if k.RevokedAt != nil {
+		enc.AddTime("revoked_at", *k.RevokedAt)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	AttemptStarted    = "started"
+	AttemptInProgress = "in_progress"
+	AttemptFinished   = "finished"
+	AttemptAbandoned  = "abandoned"
+	AttemptExpired    = "expired"
+)
+
+type Attempt struct {
+	ID                int               `gorm:"primarykey"               json:"id,omitempty"`
+	QuizID            int               `gorm:"index;not null"           json:"quiz_id"`
+	UserID            uuid.UUID         `gorm:"type:uuid;index;not null" json:"user_id"`
+	Status            string            `gorm:"not null;default:started" json:"status"`
+	Score             float64           `gorm:"not null;default:0"       json:"score"`
+	MaxScore          float64           `gorm:"not null;default:0"       json:"max_score"`
+	StartedAt         time.Time         `gorm:"not null"                 json:"started_at,omitzero"`
+	FinishedAt        *time.Time        `gorm:"default:null"             json:"finished_at,omitempty"`
+	ExpiresAt         *time.Time        `gorm:"default:null"             json:"expires_at,omitempty"`
+	CurrentQuestionID *int              `gorm:"default:null"             json:"current_question_id,omitempty"`
+	QuestionStartedAt *time.Time        `gorm:"default:null"             json:"question_started_at,omitempty"`
+	CreatedAt         time.Time         `gorm:"autoCreateTime"           json:"created_at,omitzero"`
+	UpdatedAt         time.Time         `gorm:"autoUpdateTime"           json:"updated_at,omitzero"`
+	Responses         []AttemptResponse `gorm:"foreignkey:AttemptID"     json:"responses,omitempty"`
+}
+
+func (a Attempt) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", a.ID)
+	enc.AddInt("quiz_id", a.QuizID)
+	enc.AddString("user_id", a.UserID.String())
+	enc.AddString("status", a.Status)
+	enc.AddFloat64("score", a.Score)
+	enc.AddFloat64("max_score", a.MaxScore)
+	enc.AddTime("started_at", a.StartedAt)
+	if a.FinishedAt != nil {
+		enc.AddTime("finished_at", *a.FinishedAt)
+	}
+	if a.ExpiresAt != nil {
+		enc.AddTime("expires_at", *a.ExpiresAt)
+	}
+	if a.CurrentQuestionID != nil {
+		enc.AddInt("current_question_id", *a.CurrentQuestionID)
+	}
+	return nil
+}
+
+type AttemptResponse struct {
+	ID         int       `gorm:"primarykey"     json:"id,omitempty"`
+	AttemptID  int       `gorm:"not null"       json:"attempt_id"`
+	QuestionID int       `gorm:"not null"       json:"question_id"`
+	IsCorrect  bool      `gorm:"not null"       json:"is_correct"`
+	Score      float64   `gorm:"not null"       json:"score"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at,omitzero"`
+	Choice
+}
+
+func (r AttemptResponse) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", r.ID)
+	enc.AddInt("attempt_id", r.AttemptID)
+	enc.AddInt("question_id", r.QuestionID)
+	enc.AddObject("choice", r.Choice)
+	enc.AddBool("is_correct", r.IsCorrect)
+	enc.AddFloat64("score", r.Score)
+	enc.AddTime("created_at", r.CreatedAt)
+	return nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import "go.uber.org/zap/zapcore"
+
+// Choice is what user answers to question, which of fields is used depends on
+// question type.
+type Choice struct {
+	AnswerID  int    `gorm:"default:null"               json:"answer_id,omitempty"`
+	AnswerIDs []int  `gorm:"serializer:json;type:jsonb" json:"answer_ids,omitempty"`
+	Text      string `gorm:"not null;default:''"        json:"text,omitempty"`
+}
+
+func (c Choice) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("answer_id", c.AnswerID)
+	enc.AddArray("answer_ids", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		for _, id := range c.AnswerIDs {
+			enc.AppendInt(id)
+		}
+		return nil
+	}))
+	enc.AddString("text", c.Text)
+	return nil
+}
+
+type Grade struct {
+	Correct bool    `json:"correct"`
+	Score   float64 `json:"score"`
+	// CorrectAnswerIDs is answer key, it is shown only to actors managing
+	// questions
+	CorrectAnswerIDs []int `json:"correct_answer_ids,omitempty"`
+}
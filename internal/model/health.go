@@ -0,0 +1,17 @@
+package model
+
+const (
+	HealthStatusOk   = "ok"
+	HealthStatusFail = "fail"
+)
+
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Health struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WindowDay  = "day"
+	WindowWeek = "week"
+	WindowAll  = "all"
+)
+
+type LeaderboardQuery struct {
+	QuizID *int
+	Window string
+	Since  *time.Time
+	Limit  int
+	UserID *uuid.UUID
+}
+
+type LeaderboardEntry struct {
+	Rank              int       `json:"rank"`
+	UserID            uuid.UUID `json:"user_id"`
+	Score             float64   `json:"score"`
+	Quizzes           int       `json:"quizzes"`
+	CompletionSeconds float64   `json:"completion_seconds"`
+}
+
+type Leaderboard struct {
+	QuizID  *int               `json:"quiz_id,omitempty"`
+	Window  string             `json:"window"`
+	Entries []LeaderboardEntry `json:"entries"`
+	Me      *LeaderboardEntry  `json:"me,omitempty"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+const (
+	SortIDAsc         = "id"
+	SortIDDesc        = "-id"
+	SortCreatedAtAsc  = "created_at"
+	SortCreatedAtDesc = "-created_at"
+)
+
+type ListParams struct {
+	Limit         int
+	Offset        int
+	Cursor        *Cursor
+	Sort          string
+	Query         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// Cursor points to the last item of previous page for keyset pagination.
+type Cursor struct {
+	Sort      string    `json:"s"`
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"t,omitzero"`
+}
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int64  `json:"total"`
+}
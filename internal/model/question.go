@@ -7,18 +7,54 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	SingleChoice   = "single_choice"
+	MultipleChoice = "multiple_choice"
+	FreeText       = "free_text"
+	Numeric        = "numeric"
+	Ordering       = "ordering"
+)
+
 type Question struct {
 	ID        int            `gorm:"primarykey"                                                          json:"id,omitempty"`
 	Text      string         `gorm:"not null"                                                            json:"text"`
+	Type      string         `gorm:"not null;default:single_choice"                                      json:"type"`
+	Tolerance *float64       `gorm:"default:null"                                                        json:"tolerance,omitempty"`
+	Partial   bool           `gorm:"column:partial_credit;not null;default:false"                        json:"partial_credit,omitempty"`
+	TimeLimit *int           `gorm:"column:time_limit_seconds;default:null"                              json:"time_limit_seconds,omitempty"`
+	Version   int            `gorm:"not null;default:1"                                                  json:"version"`
 	CreatedAt time.Time      `gorm:"autoCreateTime"                                                      json:"created_at,omitzero"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime"                                                      json:"updated_at,omitzero"`
 	Answers   []Answer       `gorm:"foreignkey:QuestionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"answers,omitempty"`
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+// KeyInText reports whether choices of question type are matched against text
+// of correct answers, so text of answers is itself the answer key.
+func KeyInText(questionType string) bool {
+	return questionType == FreeText || questionType == Numeric
+}
+
+// HideKey hides answer key of every answer of question, see Answer.HideKey,
+// answers of questions with key in text are omitted at all.
+func (q *Question) HideKey() {
+	if KeyInText(q.Type) {
+		q.Answers = make([]Answer, 0)
+		return
+	}
+	for i := range q.Answers {
+		q.Answers[i].HideKey()
+	}
+}
+
 func (q Question) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("id", q.ID)
 	enc.AddString("text", q.Text)
+	enc.AddString("type", q.Type)
+	enc.AddInt("version", q.Version)
+	if q.TimeLimit != nil {
+		enc.AddInt("time_limit_seconds", *q.TimeLimit)
+	}
 	enc.AddTime("created_at", q.CreatedAt)
 	enc.AddTime("updated_at", q.UpdatedAt)
 	enc.AddTime("deleted_at", q.DeletedAt.Time)
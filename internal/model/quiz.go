@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+)
+
+type Quiz struct {
+	ID          int            `gorm:"primarykey"                             json:"id,omitempty"`
+	Title       string         `gorm:"not null"                               json:"title"`
+	Description string         `gorm:"not null"                               json:"description"`
+	TimeLimit   *int           `gorm:"column:time_limit_seconds;default:null" json:"time_limit_seconds,omitempty"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"                         json:"created_at,omitzero"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"                         json:"updated_at,omitzero"`
+	QuestionIDs []int          `gorm:"-"                                      json:"question_ids,omitempty"`
+	Questions   []Question     `gorm:"-"                                      json:"questions,omitempty"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (q Quiz) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", q.ID)
+	enc.AddString("title", q.Title)
+	if q.TimeLimit != nil {
+		enc.AddInt("time_limit_seconds", *q.TimeLimit)
+	}
+	enc.AddTime("created_at", q.CreatedAt)
+	enc.AddTime("updated_at", q.UpdatedAt)
+	enc.AddTime("deleted_at", q.DeletedAt.Time)
+	enc.AddArray("question_ids", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		for _, id := range q.QuestionIDs {
+			enc.AppendInt(id)
+		}
+		return nil
+	}))
+	return nil
+}
+
+type QuizQuestion struct {
+	QuizID     int `gorm:"primaryKey"`
+	QuestionID int `gorm:"primaryKey"`
+	Position   int `gorm:"not null"`
+}
+
+type QuizQuestionsOrder struct {
+	QuestionIDs []int `json:"question_ids"`
+}
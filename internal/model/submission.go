@@ -0,0 +1,15 @@
+package model
+
+import "github.com/google/uuid"
+
+type Submission struct {
+	UserID uuid.UUID `json:"user_id"`
+	Choice
+}
+
+type SubmissionResult struct {
+	QuestionID int       `json:"question_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Choice
+	Grade
+}
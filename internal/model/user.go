@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primarykey;default:uuid_generate_v4()" json:"id"`
+	Email        string         `gorm:"not null;uniqueIndex"                            json:"email"`
+	DisplayName  string         `gorm:"not null;default:''"                             json:"display_name"`
+	PasswordHash string         `gorm:"not null"                                        json:"-"`
+	Role         string         `gorm:"not null;default:player"                         json:"role"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"                                  json:"created_at,omitzero"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"                                  json:"updated_at,omitzero"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"                                           json:"-"`
+}
+
+func (u User) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("id", u.ID.String())
+	enc.AddString("email", u.Email)
+	enc.AddString("role", u.Role)
+	enc.AddTime("created_at", u.CreatedAt)
+	enc.AddTime("updated_at", u.UpdatedAt)
+	return nil
+}
+
+type Registration struct {
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	DisplayName string `json:"display_name"`
+}
+
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// Session is issued on login, Token is bearer token of User.
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      *User     `json:"user"`
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mbilarusdev/quiz/internal/common"
 	"github.com/mbilarusdev/quiz/internal/model"
 	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
@@ -12,14 +14,44 @@ import (
 	"gorm.io/gorm"
 )
 
+// fkAnswersUserID references account of answer author.
+const fkAnswersUserID = "fk_answers_user_id"
+
 type AnswerProvider interface {
 	Insert(
 		tx *gorm.DB,
 		ctx context.Context,
 		answer *model.Answer,
 	) (*model.Answer, error)
-	GetOne(ctx context.Context, answerID int) (*model.Answer, error)
-	Delete(ctx context.Context, answerID int) (bool, error)
+	GetOne(tx *gorm.DB, ctx context.Context, answerID int) (*model.Answer, error)
+	Update(tx *gorm.DB, ctx context.Context, answer *model.Answer) (bool, error)
+	Delete(tx *gorm.DB, ctx context.Context, answerID int, version int) (bool, error)
+	// GetTrash returns up to params.Limit+1 soft deleted answers and total
+	// count of soft deleted answers matching filters
+	GetTrash(ctx context.Context, params *model.ListParams) ([]model.Answer, int64, error)
+	GetDeleted(tx *gorm.DB, ctx context.Context, answerID int) (*model.Answer, error)
+	Restore(tx *gorm.DB, ctx context.Context, answerID int) (bool, error)
+	Purge(tx *gorm.DB, ctx context.Context, answerID int, version int) (bool, error)
+	// DeleteByQuestion soft deletes answers of question marking them with
+	// deletedAt of question
+	DeleteByQuestion(tx *gorm.DB, ctx context.Context, questionID int, deletedAt time.Time) (int64, error)
+	// RestoreDeletedWith restores answers of question soft deleted at deletedAt
+	RestoreDeletedWith(tx *gorm.DB, ctx context.Context, questionID int, deletedAt time.Time) (int64, error)
+	// GetByQuestion and GetByUser return up to params.Limit+1 answers and
+	// total count of answers matching filters
+	GetByQuestion(
+		ctx context.Context,
+		questionID int,
+		params *model.ListParams,
+	) ([]model.Answer, int64, error)
+	// GetByUser skips answers of questions with key in text, see
+	// model.KeyInText, unless withKeyInText is set
+	GetByUser(
+		ctx context.Context,
+		userID uuid.UUID,
+		params *model.ListParams,
+		withKeyInText bool,
+	) ([]model.Answer, int64, error)
 }
 
 type AnswerRepository struct {
@@ -46,70 +78,422 @@ func (repo *AnswerRepository) Insert(
 	}
 	if err := gorm.G[model.Answer](db).Create(ctx, answer); err != nil {
 		if util.CheckDublicateErr(err) {
-			common.L.Error("DB error",
+			common.Log(ctx).Error("DB error",
 				zap.String("op", op),
 				zap.String("Result", "Duplicated key when create answer"),
 				zap.Object("Answer", answer))
 			return nil, &service_errors.DuplicateError{ID: answer.ID}
 		}
-		common.L.Error("DB error",
+		if util.CheckForeignKeyErr(err, fkAnswersUserID) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Author of answer has no account"),
+				zap.Object("Answer", answer))
+			return nil, &service_errors.UnknownUserError{UserID: answer.UserID}
+		}
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
 			zap.String("Result", "Error occured when create answer"),
 			zap.Object("Answer", answer))
 		return nil, err
 	}
-	common.L.Info("DB success",
+	common.Log(ctx).Info("DB success",
 		zap.String("op", op),
 		zap.String("Result", "Answer created successfully!"),
 		zap.Object("Answer", answer))
 	return answer, nil
 }
 
-func (repo *AnswerRepository) GetOne(ctx context.Context, answerID int) (*model.Answer, error) {
+// GetOne returns answer only if its question is not deleted too.
+func (repo *AnswerRepository) GetOne(
+	tx *gorm.DB,
+	ctx context.Context,
+	answerID int,
+) (*model.Answer, error) {
 	op := "repository.AnswerRepository.GetOne"
-	answer, err := gorm.G[model.Answer](repo.DB).Where("id = ?", answerID).
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	answer, err := gorm.G[model.Answer](db).
+		Where("answers.id = ?", answerID).
+		Where("EXISTS (SELECT 1 FROM questions WHERE questions.id = answers.question_id AND questions.deleted_at IS NULL)").
 		First(ctx)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			common.L.Warn("DB warn",
+			common.Log(ctx).Warn("DB warn",
 				zap.String("op", op),
 				zap.String("Result", "Answer not found"),
 				zap.Int("answer_id", answerID))
 			return nil, nil
 		}
-		common.L.Error("DB error",
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
 			zap.String("Result", "Error occured when find one answer"),
 			zap.Int("answer_id", answerID))
 		return nil, err
 	}
-	common.L.Info("DB success",
+	common.Log(ctx).Info("DB success",
 		zap.String("op", op),
 		zap.String("Result", "Answer finded successfully!"),
 		zap.Object("Answer", answer))
 	return &answer, nil
 }
 
-func (repo *AnswerRepository) Delete(ctx context.Context, answerID int) (bool, error) {
+func (repo *AnswerRepository) Update(
+	tx *gorm.DB,
+	ctx context.Context,
+	answer *model.Answer,
+) (bool, error) {
+	op := "repository.AnswerRepository.Update"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	next := *answer
+	next.Version = answer.Version + 1
+	rowsAffected, err := gorm.G[model.Answer](db).
+		Where("id = ? AND version = ?", answer.ID, answer.Version).
+		Select("text", "is_correct", "position", "version").
+		Updates(ctx, next)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when update answer"),
+			zap.Object("Answer", answer))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Updatable answer with such version not found"),
+			zap.Int("answer_id", answer.ID),
+			zap.Int("version", answer.Version))
+		return false, nil
+	}
+	answer.Version = next.Version
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Answer updated with success"),
+		zap.Object("Answer", answer))
+	return true, nil
+}
+
+// Delete soft deletes answer with given version, zero version matches any.
+func (repo *AnswerRepository) Delete(
+	tx *gorm.DB,
+	ctx context.Context,
+	answerID int,
+	version int,
+) (bool, error) {
 	op := "repository.AnswerRepository.Delete"
-	rowsAffected, err := gorm.G[model.Answer](repo.DB).Where("id = ?", answerID).Delete(ctx)
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	query := gorm.G[model.Answer](db).Where("id = ?", answerID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	rowsAffected, err := query.Delete(ctx)
 	if err != nil {
-		common.L.Error("DB error",
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
 			zap.String("Result", "Error occured when delete Answer"),
 			zap.Int("answer_id", answerID))
 		return false, err
 	}
 	if rowsAffected == 0 {
-		common.L.Warn("DB warn",
+		common.Log(ctx).Warn("DB warn",
 			zap.String("op", op),
 			zap.String("Result", "Deletable answer not found"),
 			zap.Int("answer_id", answerID))
 		return false, nil
 	}
-	common.L.Info("DB info",
+	common.Log(ctx).Info("DB info",
 		zap.String("op", op),
 		zap.String("Result", "Answer deleted with success"),
 		zap.Int("answer_id", answerID))
 	return true, nil
 }
+
+func (repo *AnswerRepository) GetByQuestion(
+	ctx context.Context,
+	questionID int,
+	params *model.ListParams,
+) ([]model.Answer, int64, error) {
+	op := "repository.AnswerRepository.GetByQuestion"
+	query := repo.DB.WithContext(ctx).Model(&model.Answer{}).Where("answers.question_id = ?", questionID)
+	answers, total, err := repo.getPage(query, params)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find page of question answers"),
+			zap.Int("question_id", questionID))
+		return nil, 0, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Page of question answers finded successfully!"),
+		zap.Int("question_id", questionID),
+		zap.Int("count", len(answers)),
+		zap.Int64("total", total))
+	return answers, total, nil
+}
+
+func (repo *AnswerRepository) GetByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	params *model.ListParams,
+	withKeyInText bool,
+) ([]model.Answer, int64, error) {
+	op := "repository.AnswerRepository.GetByUser"
+	query := repo.DB.WithContext(ctx).Model(&model.Answer{}).Where("answers.user_id = ?", userID)
+	if !withKeyInText {
+		query = query.Where(
+			"NOT EXISTS (SELECT 1 FROM questions WHERE questions.id = answers.question_id AND questions.type IN ?)",
+			[]string{model.FreeText, model.Numeric},
+		)
+	}
+	answers, total, err := repo.getPage(query, params)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find page of user answers"),
+			zap.String("user_id", userID.String()))
+		return nil, 0, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Page of user answers finded successfully!"),
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(answers)),
+		zap.Int64("total", total))
+	return answers, total, nil
+}
+
+func (repo *AnswerRepository) getPage(
+	query *gorm.DB,
+	params *model.ListParams,
+) ([]model.Answer, int64, error) {
+	query = filterList(query, "answers", "text", params)
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	answers := make([]model.Answer, 0, params.Limit+1)
+	if err := pageList(query, "answers", params).Find(&answers).Error; err != nil {
+		return nil, 0, err
+	}
+	return answers, total, nil
+}
+
+// GetTrash returns up to params.Limit+1 soft deleted answers and total count
+// of soft deleted answers matching filters.
+func (repo *AnswerRepository) GetTrash(
+	ctx context.Context,
+	params *model.ListParams,
+) ([]model.Answer, int64, error) {
+	op := "repository.AnswerRepository.GetTrash"
+	query := repo.DB.WithContext(ctx).Unscoped().Model(&model.Answer{}).Where("answers.deleted_at IS NOT NULL")
+	query = filterList(query, "answers", "text", params)
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to count deleted answers"))
+		return nil, 0, err
+	}
+	answers := make([]model.Answer, 0, params.Limit+1)
+	if err := pageList(query, "answers", params).Find(&answers).Error; err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to find page of deleted answers"))
+		return nil, 0, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Page of deleted answers finded with success"),
+		zap.Int("count", len(answers)),
+		zap.Int64("total", total))
+	return answers, total, nil
+}
+
+// GetDeleted returns answer only if it is soft deleted.
+func (repo *AnswerRepository) GetDeleted(
+	tx *gorm.DB,
+	ctx context.Context,
+	answerID int,
+) (*model.Answer, error) {
+	op := "repository.AnswerRepository.GetDeleted"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	answer, err := gorm.G[model.Answer](db.Unscoped()).
+		Where("id = ? AND deleted_at IS NOT NULL", answerID).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Deleted answer not found"),
+				zap.Int("answer_id", answerID))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find deleted answer"),
+			zap.Int("answer_id", answerID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Deleted answer finded successfully!"),
+		zap.Object("Answer", answer))
+	return &answer, nil
+}
+
+// Restore clears deleted_at of soft deleted answer and bumps its version.
+func (repo *AnswerRepository) Restore(tx *gorm.DB, ctx context.Context, answerID int) (bool, error) {
+	op := "repository.AnswerRepository.Restore"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	result := db.WithContext(ctx).Unscoped().Model(&model.Answer{}).
+		Where("id = ? AND deleted_at IS NOT NULL", answerID).
+		Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when restore answer"),
+			zap.Int("answer_id", answerID))
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Restorable answer not found"),
+			zap.Int("answer_id", answerID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Answer restored with success"),
+		zap.Int("answer_id", answerID))
+	return true, nil
+}
+
+// Purge permanently deletes answer with given version whether it is soft
+// deleted or not, zero version matches any.
+func (repo *AnswerRepository) Purge(
+	tx *gorm.DB,
+	ctx context.Context,
+	answerID int,
+	version int,
+) (bool, error) {
+	op := "repository.AnswerRepository.Purge"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	query := gorm.G[model.Answer](db.Unscoped()).Where("id = ?", answerID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	rowsAffected, err := query.Delete(ctx)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when purge Answer"),
+			zap.Int("answer_id", answerID))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Purgeable answer not found"),
+			zap.Int("answer_id", answerID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Answer purged with success"),
+		zap.Int("answer_id", answerID))
+	return true, nil
+}
+
+func (repo *AnswerRepository) RestoreDeletedWith(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionID int,
+	deletedAt time.Time,
+) (int64, error) {
+	op := "repository.AnswerRepository.RestoreDeletedWith"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	result := db.WithContext(ctx).Unscoped().Model(&model.Answer{}).
+		Where("question_id = ? AND deleted_at = ?", questionID, deletedAt).
+		Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when restore answers of question"),
+			zap.Int("question_id", questionID))
+		return 0, result.Error
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Answers of question restored with success"),
+		zap.Int("question_id", questionID),
+		zap.Int64("count", result.RowsAffected))
+	return result.RowsAffected, nil
+}
+
+func (repo *AnswerRepository) DeleteByQuestion(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionID int,
+	deletedAt time.Time,
+) (int64, error) {
+	op := "repository.AnswerRepository.DeleteByQuestion"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	result := db.WithContext(ctx).Model(&model.Answer{}).
+		Where("question_id = ?", questionID).
+		UpdateColumn("deleted_at", deletedAt)
+	if result.Error != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when delete answers of question"),
+			zap.Int("question_id", questionID))
+		return 0, result.Error
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Answers of question deleted with success"),
+		zap.Int("question_id", questionID),
+		zap.Int64("count", result.RowsAffected))
+	return result.RowsAffected, nil
+}
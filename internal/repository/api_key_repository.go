@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type APIKeyProvider interface {
+	Insert(ctx context.Context, key *model.APIKey) (*model.APIKey, error)
+	GetAll(ctx context.Context) ([]model.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	Revoke(ctx context.Context, keyID int, revokedAt time.Time) (bool, error)
+	SetLastUsed(ctx context.Context, keyID int, usedAt time.Time) error
+}
+
+type APIKeyRepository struct {
+	DB *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	repo := new(APIKeyRepository)
+	repo.DB = db
+	return repo
+}
+
+func (repo *APIKeyRepository) Insert(ctx context.Context, key *model.APIKey) (*model.APIKey, error) {
+	op := "repository.APIKeyRepository.Insert"
+	if err := gorm.G[model.APIKey](repo.DB).Create(ctx, key); err != nil {
+		if util.CheckDublicateErr(err) {
+			common.Log(ctx).Error("DB error",
+				zap.String("op", op),
+				zap.String("Result", "Duplicated key when create api key"),
+				zap.Object("APIKey", key))
+			return nil, &service_errors.DuplicateError{ID: key.ID}
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when create api key"),
+			zap.Object("APIKey", key))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "API key created successfully!"),
+		zap.Object("APIKey", key))
+	return key, nil
+}
+
+func (repo *APIKeyRepository) GetAll(ctx context.Context) ([]model.APIKey, error) {
+	op := "repository.APIKeyRepository.GetAll"
+	keys, err := gorm.G[model.APIKey](repo.DB).Order("id ASC").Find(ctx)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find api keys"))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "API keys finded successfully!"),
+		zap.Int("count", len(keys)))
+	return keys, nil
+}
+
+func (repo *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	op := "repository.APIKeyRepository.GetByHash"
+	key, err := gorm.G[model.APIKey](repo.DB).Where("key_hash = ?", keyHash).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "API key not found"))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find api key by hash"))
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (repo *APIKeyRepository) Revoke(ctx context.Context, keyID int, revokedAt time.Time) (bool, error) {
+	op := "repository.APIKeyRepository.Revoke"
+	rowsAffected, err := gorm.G[model.APIKey](repo.DB).
+		Where("id = ? AND revoked_at IS NULL", keyID).
+		Update(ctx, "revoked_at", revokedAt)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when revoke api key"),
+			zap.Int("api_key_id", keyID))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Active api key to revoke not found"),
+			zap.Int("api_key_id", keyID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "API key revoked with success"),
+		zap.Int("api_key_id", keyID))
+	return true, nil
+}
+
+func (repo *APIKeyRepository) SetLastUsed(ctx context.Context, keyID int, usedAt time.Time) error {
+	op := "repository.APIKeyRepository.SetLastUsed"
+	err := repo.DB.WithContext(ctx).Model(&model.APIKey{}).
+		Where("id = ?", keyID).
+		UpdateColumn("last_used_at", usedAt).Error
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when set last usage of api key"),
+			zap.Int("api_key_id", keyID))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttemptProvider interface {
+	Insert(
+		tx *gorm.DB,
+		ctx context.Context,
+		attempt *model.Attempt,
+	) (*model.Attempt, error)
+	GetOne(ctx context.Context, attemptID int) (*model.Attempt, error)
+	GetOneForUpdate(tx *gorm.DB, ctx context.Context, attemptID int) (*model.Attempt, error)
+	UpdateState(tx *gorm.DB, ctx context.Context, attempt *model.Attempt) error
+	InsertResponse(
+		tx *gorm.DB,
+		ctx context.Context,
+		response *model.AttemptResponse,
+	) (*model.AttemptResponse, error)
+	GetResponses(tx *gorm.DB, ctx context.Context, attemptID int) ([]model.AttemptResponse, error)
+	GetExpiredIDs(ctx context.Context, now time.Time, limit int) ([]int, error)
+}
+
+type AttemptRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttemptRepository(db *gorm.DB) *AttemptRepository {
+	repo := new(AttemptRepository)
+	repo.DB = db
+	return repo
+}
+
+func (repo *AttemptRepository) Insert(
+	tx *gorm.DB,
+	ctx context.Context,
+	attempt *model.Attempt,
+) (*model.Attempt, error) {
+	op := "repository.AttemptRepository.Insert"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	if err := gorm.G[model.Attempt](db).Create(ctx, attempt); err != nil {
+		if util.CheckDublicateErr(err) {
+			common.Log(ctx).Error("DB error",
+				zap.String("op", op),
+				zap.String("Result", "Duplicated key when create attempt"),
+				zap.Object("Attempt", attempt))
+			return nil, &service_errors.DuplicateError{ID: attempt.ID}
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when create attempt"),
+			zap.Object("Attempt", attempt))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Attempt created successfully!"),
+		zap.Object("Attempt", attempt))
+	return attempt, nil
+}
+
+func (repo *AttemptRepository) GetOne(ctx context.Context, attemptID int) (*model.Attempt, error) {
+	op := "repository.AttemptRepository.GetOne"
+	attempt, err := gorm.G[model.Attempt](repo.DB).
+		Where("id = ?", attemptID).
+		Preload("Responses", func(db gorm.PreloadBuilder) error {
+			db.Order("created_at ASC")
+			return nil
+		}).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Attempt not found"),
+				zap.Int("attempt_id", attemptID))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find one attempt"),
+			zap.Int("attempt_id", attemptID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Attempt finded successfully!"),
+		zap.Object("Attempt", attempt))
+	return &attempt, nil
+}
+
+func (repo *AttemptRepository) GetOneForUpdate(
+	tx *gorm.DB,
+	ctx context.Context,
+	attemptID int,
+) (*model.Attempt, error) {
+	op := "repository.AttemptRepository.GetOneForUpdate"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	attempt := &model.Attempt{}
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", attemptID).
+		First(attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Attempt not found"),
+				zap.Int("attempt_id", attemptID))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when lock attempt"),
+			zap.Int("attempt_id", attemptID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Attempt locked successfully!"),
+		zap.Object("Attempt", attempt))
+	return attempt, nil
+}
+
+func (repo *AttemptRepository) UpdateState(
+	tx *gorm.DB,
+	ctx context.Context,
+	attempt *model.Attempt,
+) error {
+	op := "repository.AttemptRepository.UpdateState"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	_, err := gorm.G[model.Attempt](db).
+		Where("id = ?", attempt.ID).
+		Select(
+			"status",
+			"score",
+			"max_score",
+			"finished_at",
+			"current_question_id",
+			"question_started_at",
+		).
+		Updates(ctx, *attempt)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when update attempt state"),
+			zap.Object("Attempt", attempt))
+		return err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Attempt state updated successfully!"),
+		zap.Object("Attempt", attempt))
+	return nil
+}
+
+func (repo *AttemptRepository) InsertResponse(
+	tx *gorm.DB,
+	ctx context.Context,
+	response *model.AttemptResponse,
+) (*model.AttemptResponse, error) {
+	op := "repository.AttemptRepository.InsertResponse"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	if err := gorm.G[model.AttemptResponse](db).Create(ctx, response); err != nil {
+		if util.CheckDublicateErr(err) {
+			common.Log(ctx).Error("DB error",
+				zap.String("op", op),
+				zap.String("Result", "Question of attempt already answered"),
+				zap.Object("AttemptResponse", response))
+			return nil, &service_errors.DuplicateError{ID: response.QuestionID}
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when create attempt response"),
+			zap.Object("AttemptResponse", response))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Attempt response created successfully!"),
+		zap.Object("AttemptResponse", response))
+	return response, nil
+}
+
+func (repo *AttemptRepository) GetResponses(
+	tx *gorm.DB,
+	ctx context.Context,
+	attemptID int,
+) ([]model.AttemptResponse, error) {
+	op := "repository.AttemptRepository.GetResponses"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	responses, err := gorm.G[model.AttemptResponse](db).
+		Where("attempt_id = ?", attemptID).
+		Order("created_at ASC").
+		Find(ctx)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find responses of attempt"),
+			zap.Int("attempt_id", attemptID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Responses of attempt finded successfully!"),
+		zap.Int("attempt_id", attemptID),
+		zap.Int("count", len(responses)))
+	return responses, nil
+}
+
+func (repo *AttemptRepository) GetExpiredIDs(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]int, error) {
+	op := "repository.AttemptRepository.GetExpiredIDs"
+	ids := make([]int, 0)
+	err := repo.DB.WithContext(ctx).
+		Model(&model.Attempt{}).
+		Where("status IN ?", []string{model.AttemptStarted, model.AttemptInProgress}).
+		Where("expires_at < ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find expired attempts"))
+		return nil, err
+	}
+	common.Log(ctx).Debug("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Expired attempts finded successfully!"),
+		zap.Int("count", len(ids)))
+	return ids, nil
+}
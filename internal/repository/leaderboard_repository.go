@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type LeaderboardProvider interface {
+	GetTop(ctx context.Context, query *model.LeaderboardQuery) ([]model.LeaderboardEntry, error)
+	GetEntry(
+		ctx context.Context,
+		query *model.LeaderboardQuery,
+		userID uuid.UUID,
+	) (*model.LeaderboardEntry, error)
+}
+
+type LeaderboardRepository struct {
+	DB *gorm.DB
+}
+
+func NewLeaderboardRepository(db *gorm.DB) *LeaderboardRepository {
+	repo := new(LeaderboardRepository)
+	repo.DB = db
+	return repo
+}
+
+// rankedSQL takes best closed attempt of every user in every quiz, sums them
+// per user and ranks users by score, ties are broken by total completion time.
+const rankedSQL = `
+WITH best AS (
+	SELECT DISTINCT ON (user_id, quiz_id)
+		user_id,
+		score,
+		EXTRACT(EPOCH FROM finished_at - started_at) AS duration
+	FROM attempts
+	WHERE status IN ('finished', 'expired')
+		AND (CAST(@quiz_id AS INT) IS NULL OR quiz_id = @quiz_id)
+		AND (CAST(@since AS TIMESTAMPTZ) IS NULL OR finished_at >= @since)
+	ORDER BY user_id, quiz_id, score DESC, finished_at - started_at ASC
+), ranked AS (
+	SELECT
+		RANK() OVER (ORDER BY SUM(score) DESC, SUM(duration) ASC) AS rank,
+		user_id,
+		SUM(score) AS score,
+		COUNT(*) AS quizzes,
+		SUM(duration) AS completion_seconds
+	FROM best
+	GROUP BY user_id
+)
+`
+
+func leaderboardArgs(query *model.LeaderboardQuery) map[string]any {
+	return map[string]any{
+		"quiz_id": query.QuizID,
+		"since":   query.Since,
+		"limit":   query.Limit,
+	}
+}
+
+func (repo *LeaderboardRepository) GetTop(
+	ctx context.Context,
+	query *model.LeaderboardQuery,
+) ([]model.LeaderboardEntry, error) {
+	op := "repository.LeaderboardRepository.GetTop"
+	entries := make([]model.LeaderboardEntry, 0, query.Limit)
+	err := repo.DB.WithContext(ctx).
+		Raw(rankedSQL+`SELECT * FROM ranked ORDER BY rank ASC, user_id ASC LIMIT @limit`, leaderboardArgs(query)).
+		Scan(&entries).Error
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when rank leaderboard"),
+			zap.String("window", query.Window))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Leaderboard ranked successfully!"),
+		zap.String("window", query.Window),
+		zap.Int("count", len(entries)))
+	return entries, nil
+}
+
+func (repo *LeaderboardRepository) GetEntry(
+	ctx context.Context,
+	query *model.LeaderboardQuery,
+	userID uuid.UUID,
+) (*model.LeaderboardEntry, error) {
+	op := "repository.LeaderboardRepository.GetEntry"
+	args := leaderboardArgs(query)
+	args["user_id"] = userID
+	entries := make([]model.LeaderboardEntry, 0, 1)
+	err := repo.DB.WithContext(ctx).
+		Raw(rankedSQL+`SELECT * FROM ranked WHERE user_id = @user_id`, args).
+		Scan(&entries).Error
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find user rank"),
+			zap.String("user_id", userID.String()))
+		return nil, err
+	}
+	if len(entries) == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "User is not ranked"),
+			zap.String("user_id", userID.String()))
+		return nil, nil
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "User rank finded successfully!"),
+		zap.String("user_id", userID.String()),
+		zap.Int("rank", entries[0].Rank))
+	return &entries[0], nil
+}
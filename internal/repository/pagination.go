@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"gorm.io/gorm"
+)
+
+// filterList applies created_at filters and text search on column of table.
+func filterList(db *gorm.DB, table string, textColumn string, params *model.ListParams) *gorm.DB {
+	if params.CreatedAfter != nil {
+		db = db.Where(table+".created_at > ?", *params.CreatedAfter)
+	}
+	if params.CreatedBefore != nil {
+		db = db.Where(table+".created_at < ?", *params.CreatedBefore)
+	}
+	if params.Query != "" {
+		db = db.Where(table+"."+textColumn+" ILIKE ?", "%"+escapeLike(params.Query)+"%")
+	}
+	return db
+}
+
+// pageList applies sorting and cursor or offset, it selects one extra row, so
+// caller knows whether next page exists.
+func pageList(db *gorm.DB, table string, params *model.ListParams) *gorm.DB {
+	id, createdAt := table+".id", table+".created_at"
+	if cursor := params.Cursor; cursor != nil {
+		switch params.Sort {
+		case model.SortIDAsc:
+			db = db.Where(id+" > ?", cursor.ID)
+		case model.SortIDDesc:
+			db = db.Where(id+" < ?", cursor.ID)
+		case model.SortCreatedAtAsc:
+			db = db.Where("("+createdAt+", "+id+") > (?, ?)", cursor.CreatedAt, cursor.ID)
+		case model.SortCreatedAtDesc:
+			db = db.Where("("+createdAt+", "+id+") < (?, ?)", cursor.CreatedAt, cursor.ID)
+		}
+	} else if params.Offset > 0 {
+		db = db.Offset(params.Offset)
+	}
+	switch params.Sort {
+	case model.SortIDAsc:
+		db = db.Order(id + " ASC")
+	case model.SortIDDesc:
+		db = db.Order(id + " DESC")
+	case model.SortCreatedAtAsc:
+		db = db.Order(createdAt + " ASC").Order(id + " ASC")
+	case model.SortCreatedAtDesc:
+		db = db.Order(createdAt + " DESC").Order(id + " DESC")
+	}
+	return db.Limit(params.Limit + 1)
+}
+
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
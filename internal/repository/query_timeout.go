@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	queryParentKey = "query_timeout:parent"
+	queryCancelKey = "query_timeout:cancel"
+)
+
+// QueryTimeout bounds every query of gorm.DB by its own deadline, so one
+// slow query is cancelled without limiting other queries of request.
+type QueryTimeout struct {
+	Timeout time.Duration
+}
+
+func NewQueryTimeout(timeout time.Duration) *QueryTimeout {
+	plugin := new(QueryTimeout)
+	plugin.Timeout = timeout
+	return plugin
+}
+
+func (plugin *QueryTimeout) Name() string {
+	return "query_timeout"
+}
+
+// Initialize registers callbacks around queries. Rows of row callbacks are
+// scanned after callbacks return, so their deadline is left to expire instead
+// of being cancelled.
+func (plugin *QueryTimeout) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("*").Register("query_timeout:before_create", plugin.before),
+		callback.Create().After("*").Register("query_timeout:after_create", plugin.after),
+		callback.Query().Before("*").Register("query_timeout:before_query", plugin.before),
+		callback.Query().After("*").Register("query_timeout:after_query", plugin.after),
+		callback.Update().Before("*").Register("query_timeout:before_update", plugin.before),
+		callback.Update().After("*").Register("query_timeout:after_update", plugin.after),
+		callback.Delete().Before("*").Register("query_timeout:before_delete", plugin.before),
+		callback.Delete().After("*").Register("query_timeout:after_delete", plugin.after),
+		callback.Row().Before("*").Register("query_timeout:before_row", plugin.before),
+		callback.Raw().Before("*").Register("query_timeout:before_raw", plugin.before),
+		callback.Raw().After("*").Register("query_timeout:after_raw", plugin.after),
+	)
+}
+
+func (plugin *QueryTimeout) before(db *gorm.DB) {
+	parent := db.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, plugin.Timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(queryParentKey, parent)
+	db.InstanceSet(queryCancelKey, cancel)
+}
+
+// after cancels deadline of finished query and restores context of
+// statement, so statement can be reused by next query.
+func (plugin *QueryTimeout) after(db *gorm.DB) {
+	if value, ok := db.InstanceGet(queryCancelKey); ok {
+		if cancel, ok := value.(context.CancelFunc); ok {
+			cancel()
+		}
+	}
+	if value, ok := db.InstanceGet(queryParentKey); ok {
+		if parent, ok := value.(context.Context); ok {
+			db.Statement.Context = parent
+		}
+	}
+}
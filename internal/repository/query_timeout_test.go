@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true},
+	)
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+	timeout := time.Minute
+	if err := db.Use(NewQueryTimeout(timeout)); err != nil {
+		t.Fatalf("use plugin: %v", err)
+	}
+	var deadlines []time.Time
+	err = db.Callback().Query().Before("gorm:query").Register("test:deadline", func(db *gorm.DB) {
+		deadline, ok := db.Statement.Context.Deadline()
+		if !ok {
+			t.Error("query context has no deadline")
+		}
+		deadlines = append(deadlines, deadline)
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := time.Now()
+	query := db.WithContext(parent).Model(&model.Question{}).Where("id = ?", 1)
+	var questions []model.Question
+	if err := query.Find(&questions).Error; err != nil {
+		t.Fatalf("first query: %v", err)
+	}
+	if err := query.Find(&questions).Error; err != nil {
+		t.Fatalf("second query on reused statement: %v", err)
+	}
+
+	if len(deadlines) != 2 {
+		t.Fatalf("got %v queries, want 2", len(deadlines))
+	}
+	for i, deadline := range deadlines {
+		if deadline.Before(started.Add(timeout)) || deadline.After(time.Now().Add(timeout)) {
+			t.Errorf("deadline of query %v is %v, want %v after start of query", i, deadline, timeout)
+		}
+	}
+	if query.Statement.Context != parent {
+		t.Error("context of statement is not restored after query")
+	}
+}
@@ -20,8 +20,20 @@ type QuestionProvider interface {
 		questionID int,
 		withAnswers bool,
 	) (*model.Question, error)
-	GetAll(ctx context.Context) ([]model.Question, error)
-	Delete(ctx context.Context, questionID int) (bool, error)
+	// GetAll returns up to params.Limit+1 questions and total count of
+	// questions matching filters
+	GetAll(ctx context.Context, params *model.ListParams) ([]model.Question, int64, error)
+	GetMany(tx *gorm.DB, ctx context.Context, questionIDs []int) ([]model.Question, error)
+	Update(tx *gorm.DB, ctx context.Context, question *model.Question) (bool, error)
+	// Touch bumps version of question, used when answers of question change
+	Touch(tx *gorm.DB, ctx context.Context, questionID int) error
+	Delete(tx *gorm.DB, ctx context.Context, questionID int, version int) (bool, error)
+	// GetTrash returns up to params.Limit+1 soft deleted questions and total
+	// count of soft deleted questions matching filters
+	GetTrash(ctx context.Context, params *model.ListParams) ([]model.Question, int64, error)
+	GetDeleted(tx *gorm.DB, ctx context.Context, questionID int) (*model.Question, error)
+	Restore(tx *gorm.DB, ctx context.Context, questionID int) (bool, error)
+	Purge(tx *gorm.DB, ctx context.Context, questionID int, version int) (bool, error)
 }
 
 type QuestionRepository struct {
@@ -41,19 +53,27 @@ func (repo *QuestionRepository) Insert(
 	op := "repository.QuestionRepository.Insert"
 	if err := gorm.G[model.Question](repo.DB).Create(ctx, question); err != nil {
 		if util.CheckDublicateErr(err) {
-			common.L.Error("DB error",
+			common.Log(ctx).Error("DB error",
 				zap.String("op", op),
 				zap.String("Result", "Duplicated key when create question"),
 				zap.Object("Question", question))
 			return nil, &service_errors.DuplicateError{ID: question.ID}
 		}
-		common.L.Error("DB error",
+		// Answers created together with question share author
+		if util.CheckForeignKeyErr(err, fkAnswersUserID) && len(question.Answers) > 0 {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Author of question answers has no account"),
+				zap.Object("Question", question))
+			return nil, &service_errors.UnknownUserError{UserID: question.Answers[0].UserID}
+		}
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
 			zap.String("Result", "Error occured when create question"),
 			zap.Object("Question", question))
 		return nil, err
 	}
-	common.L.Info("DB success",
+	common.Log(ctx).Info("DB success",
 		zap.String("op", op),
 		zap.String("Result", "Question created successfully!"),
 		zap.Object("Question", question))
@@ -85,62 +105,324 @@ func (repo *QuestionRepository) GetOne(
 	question, err := query.First(ctx)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			common.L.Warn("DB warn",
+			common.Log(ctx).Warn("DB warn",
 				zap.String("op", op),
 				zap.String("Result", "Question not found"),
 				zap.Int("question_id", questionID))
 			return nil, nil
 		}
-		common.L.Error("DB error",
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
 			zap.String("Result", "Error occured when find one question with answers"),
 			zap.Int("question_id", questionID))
 		return nil, err
 	}
-	common.L.Info("DB success",
+	common.Log(ctx).Info("DB success",
 		zap.String("op", op),
 		zap.String("Result", "Question with answers finded successfully!"),
 		zap.Object("Question", question))
 	return &question, nil
 }
 
-func (repo *QuestionRepository) GetAll(ctx context.Context) ([]model.Question, error) {
+func (repo *QuestionRepository) GetAll(
+	ctx context.Context,
+	params *model.ListParams,
+) ([]model.Question, int64, error) {
 	op := "repository.QuestionRepository.GetAll"
-	questions, err := gorm.G[model.Question](
-		repo.DB,
-	).Find(ctx)
+	query := filterList(repo.DB.WithContext(ctx).Model(&model.Question{}), "questions", "text", params)
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to count questions"))
+		return nil, 0, err
+	}
+	questions := make([]model.Question, 0, params.Limit+1)
+	if err := pageList(query, "questions", params).Find(&questions).Error; err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to find page of questions"))
+		return nil, 0, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Page of questions finded with success"),
+		zap.Int("count", len(questions)),
+		zap.Int64("total", total))
+	return questions, total, nil
+}
+
+func (repo *QuestionRepository) GetMany(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionIDs []int,
+) ([]model.Question, error) {
+	op := "repository.QuestionRepository.GetMany"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	questions, err := gorm.G[model.Question](db).Where("id IN ?", questionIDs).Find(ctx)
 	if err != nil {
-		common.L.Error("DB error",
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
-			zap.String("Result", "Error occured when try to find all questions"))
-		return make([]model.Question, 0), nil
+			zap.String("Result", "Error occured when find questions by ids"),
+			zap.Ints("question_ids", questionIDs))
+		return nil, err
 	}
-	common.L.Error("DB success",
+	common.Log(ctx).Info("DB success",
 		zap.String("op", op),
-		zap.String("Result", "All questions finded with success"))
+		zap.String("Result", "Questions by ids finded successfully!"),
+		zap.Ints("question_ids", questionIDs))
 	return questions, nil
 }
 
-func (repo *QuestionRepository) Delete(ctx context.Context, questionID int) (bool, error) {
+func (repo *QuestionRepository) Update(
+	tx *gorm.DB,
+	ctx context.Context,
+	question *model.Question,
+) (bool, error) {
+	op := "repository.QuestionRepository.Update"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	next := *question
+	next.Version = question.Version + 1
+	rowsAffected, err := gorm.G[model.Question](db).
+		Where("id = ? AND version = ?", question.ID, question.Version).
+		Select("text", "type", "tolerance", "partial_credit", "time_limit_seconds", "version").
+		Updates(ctx, next)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when update question"),
+			zap.Object("Question", question))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Updatable question with such version not found"),
+			zap.Int("question_id", question.ID),
+			zap.Int("version", question.Version))
+		return false, nil
+	}
+	question.Version = next.Version
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Question updated with success"),
+		zap.Object("Question", question))
+	return true, nil
+}
+
+func (repo *QuestionRepository) Touch(tx *gorm.DB, ctx context.Context, questionID int) error {
+	op := "repository.QuestionRepository.Touch"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	_, err := gorm.G[model.Question](db).
+		Where("id = ?", questionID).
+		Update(ctx, "version", gorm.Expr("version + 1"))
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when bump question version"),
+			zap.Int("question_id", questionID))
+		return err
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Question version bumped with success"),
+		zap.Int("question_id", questionID))
+	return nil
+}
+
+// Delete soft deletes question with given version, zero version matches any.
+func (repo *QuestionRepository) Delete(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionID int,
+	version int,
+) (bool, error) {
 	op := "repository.QuestionRepository.Delete"
-	rowsAffected, err := gorm.G[model.Question](repo.DB).Where("id = ?", questionID).Delete(ctx)
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	query := gorm.G[model.Question](db).Where("id = ?", questionID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	rowsAffected, err := query.Delete(ctx)
 	if err != nil {
-		common.L.Error("DB error",
+		common.Log(ctx).Error("DB error",
 			zap.String("op", op),
 			zap.String("Result", "Error occured when delete Question"),
 			zap.Int("question_id", questionID))
 		return false, err
 	}
 	if rowsAffected == 0 {
-		common.L.Warn("DB warn",
+		common.Log(ctx).Warn("DB warn",
 			zap.String("op", op),
 			zap.String("Result", "Deletable question not found"),
 			zap.Int("question_id", questionID))
 		return false, nil
 	}
-	common.L.Info("DB info",
+	common.Log(ctx).Info("DB info",
 		zap.String("op", op),
 		zap.String("Result", "Question deleted with success"),
 		zap.Int("question_id", questionID))
 	return true, nil
 }
+
+// GetTrash returns up to params.Limit+1 soft deleted questions and total count
+// of soft deleted questions matching filters.
+func (repo *QuestionRepository) GetTrash(
+	ctx context.Context,
+	params *model.ListParams,
+) ([]model.Question, int64, error) {
+	op := "repository.QuestionRepository.GetTrash"
+	query := repo.DB.WithContext(ctx).Unscoped().Model(&model.Question{}).Where("questions.deleted_at IS NOT NULL")
+	query = filterList(query, "questions", "text", params)
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to count deleted questions"))
+		return nil, 0, err
+	}
+	questions := make([]model.Question, 0, params.Limit+1)
+	if err := pageList(query, "questions", params).Find(&questions).Error; err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to find page of deleted questions"))
+		return nil, 0, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Page of deleted questions finded with success"),
+		zap.Int("count", len(questions)),
+		zap.Int64("total", total))
+	return questions, total, nil
+}
+
+// GetDeleted returns question only if it is soft deleted.
+func (repo *QuestionRepository) GetDeleted(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionID int,
+) (*model.Question, error) {
+	op := "repository.QuestionRepository.GetDeleted"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	question, err := gorm.G[model.Question](db.Unscoped()).
+		Where("id = ? AND deleted_at IS NOT NULL", questionID).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Deleted question not found"),
+				zap.Int("question_id", questionID))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find deleted question"),
+			zap.Int("question_id", questionID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Deleted question finded successfully!"),
+		zap.Object("Question", question))
+	return &question, nil
+}
+
+// Restore clears deleted_at of soft deleted question and bumps its version.
+func (repo *QuestionRepository) Restore(tx *gorm.DB, ctx context.Context, questionID int) (bool, error) {
+	op := "repository.QuestionRepository.Restore"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	result := db.WithContext(ctx).Unscoped().Model(&model.Question{}).
+		Where("id = ? AND deleted_at IS NOT NULL", questionID).
+		Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when restore question"),
+			zap.Int("question_id", questionID))
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Restorable question not found"),
+			zap.Int("question_id", questionID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Question restored with success"),
+		zap.Int("question_id", questionID))
+	return true, nil
+}
+
+// Purge permanently deletes question with given version whether it is soft
+// deleted or not, zero version matches any.
+func (repo *QuestionRepository) Purge(
+	tx *gorm.DB,
+	ctx context.Context,
+	questionID int,
+	version int,
+) (bool, error) {
+	op := "repository.QuestionRepository.Purge"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	query := gorm.G[model.Question](db.Unscoped()).Where("id = ?", questionID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	rowsAffected, err := query.Delete(ctx)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when purge Question"),
+			zap.Int("question_id", questionID))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Purgeable question not found"),
+			zap.Int("question_id", questionID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Question purged with success"),
+		zap.Int("question_id", questionID))
+	return true, nil
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type QuizProvider interface {
+	Insert(
+		tx *gorm.DB,
+		ctx context.Context,
+		quiz *model.Quiz,
+	) (*model.Quiz, error)
+	GetOne(tx *gorm.DB, ctx context.Context, quizID int) (*model.Quiz, error)
+	GetAll(ctx context.Context) ([]model.Quiz, error)
+	Update(ctx context.Context, quiz *model.Quiz) (bool, error)
+	Delete(ctx context.Context, quizID int) (bool, error)
+	GetQuestions(tx *gorm.DB, ctx context.Context, quizID int) ([]model.Question, error)
+	ReplaceQuestions(
+		tx *gorm.DB,
+		ctx context.Context,
+		quizID int,
+		questionIDs []int,
+	) error
+}
+
+type QuizRepository struct {
+	DB *gorm.DB
+}
+
+func NewQuizRepository(db *gorm.DB) *QuizRepository {
+	repo := new(QuizRepository)
+	repo.DB = db
+	return repo
+}
+
+func (repo *QuizRepository) Insert(
+	tx *gorm.DB,
+	ctx context.Context,
+	quiz *model.Quiz,
+) (*model.Quiz, error) {
+	op := "repository.QuizRepository.Insert"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	if err := gorm.G[model.Quiz](db).Create(ctx, quiz); err != nil {
+		if util.CheckDublicateErr(err) {
+			common.Log(ctx).Error("DB error",
+				zap.String("op", op),
+				zap.String("Result", "Duplicated key when create quiz"),
+				zap.Object("Quiz", quiz))
+			return nil, &service_errors.DuplicateError{ID: quiz.ID}
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when create quiz"),
+			zap.Object("Quiz", quiz))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Quiz created successfully!"),
+		zap.Object("Quiz", quiz))
+	return quiz, nil
+}
+
+func (repo *QuizRepository) GetOne(
+	tx *gorm.DB,
+	ctx context.Context,
+	quizID int,
+) (*model.Quiz, error) {
+	op := "repository.QuizRepository.GetOne"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	quiz, err := gorm.G[model.Quiz](db).Where("id = ?", quizID).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Quiz not found"),
+				zap.Int("quiz_id", quizID))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find one quiz"),
+			zap.Int("quiz_id", quizID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Quiz finded successfully!"),
+		zap.Object("Quiz", quiz))
+	return &quiz, nil
+}
+
+func (repo *QuizRepository) GetAll(ctx context.Context) ([]model.Quiz, error) {
+	op := "repository.QuizRepository.GetAll"
+	quizzes, err := gorm.G[model.Quiz](repo.DB).Order("id ASC").Find(ctx)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when try to find all quizzes"))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "All quizzes finded with success"))
+	return quizzes, nil
+}
+
+func (repo *QuizRepository) Update(ctx context.Context, quiz *model.Quiz) (bool, error) {
+	op := "repository.QuizRepository.Update"
+	rowsAffected, err := gorm.G[model.Quiz](repo.DB).
+		Where("id = ?", quiz.ID).
+		Select("title", "description", "time_limit_seconds").
+		Updates(ctx, *quiz)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when update quiz"),
+			zap.Object("Quiz", quiz))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Updatable quiz not found"),
+			zap.Int("quiz_id", quiz.ID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Quiz updated with success"),
+		zap.Object("Quiz", quiz))
+	return true, nil
+}
+
+func (repo *QuizRepository) Delete(ctx context.Context, quizID int) (bool, error) {
+	op := "repository.QuizRepository.Delete"
+	rowsAffected, err := gorm.G[model.Quiz](repo.DB).Where("id = ?", quizID).Delete(ctx)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when delete Quiz"),
+			zap.Int("quiz_id", quizID))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Deletable quiz not found"),
+			zap.Int("quiz_id", quizID))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "Quiz deleted with success"),
+		zap.Int("quiz_id", quizID))
+	return true, nil
+}
+
+func (repo *QuizRepository) GetQuestions(
+	tx *gorm.DB,
+	ctx context.Context,
+	quizID int,
+) ([]model.Question, error) {
+	op := "repository.QuizRepository.GetQuestions"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	questions := make([]model.Question, 0)
+	err := db.WithContext(ctx).
+		Joins("JOIN quiz_questions ON quiz_questions.question_id = questions.id").
+		Where("quiz_questions.quiz_id = ?", quizID).
+		Order("quiz_questions.position ASC").
+		Find(&questions).Error
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find questions of quiz"),
+			zap.Int("quiz_id", quizID))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Questions of quiz finded successfully!"),
+		zap.Int("quiz_id", quizID),
+		zap.Int("count", len(questions)))
+	return questions, nil
+}
+
+func (repo *QuizRepository) ReplaceQuestions(
+	tx *gorm.DB,
+	ctx context.Context,
+	quizID int,
+	questionIDs []int,
+) error {
+	op := "repository.QuizRepository.ReplaceQuestions"
+	var db *gorm.DB
+	if tx != nil {
+		db = tx
+	} else {
+		db = repo.DB
+	}
+	if _, err := gorm.G[model.QuizQuestion](db).Where("quiz_id = ?", quizID).Delete(ctx); err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when clear questions of quiz"),
+			zap.Int("quiz_id", quizID))
+		return err
+	}
+	if len(questionIDs) == 0 {
+		return nil
+	}
+	links := make([]model.QuizQuestion, 0, len(questionIDs))
+	for i, questionID := range questionIDs {
+		links = append(links, model.QuizQuestion{
+			QuizID:     quizID,
+			QuestionID: questionID,
+			Position:   i + 1,
+		})
+	}
+	if err := gorm.G[model.QuizQuestion](db).CreateInBatches(ctx, &links, len(links)); err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when link questions to quiz"),
+			zap.Int("quiz_id", quizID),
+			zap.Ints("question_ids", questionIDs))
+		return err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "Questions of quiz replaced successfully!"),
+		zap.Int("quiz_id", quizID),
+		zap.Ints("question_ids", questionIDs))
+	return nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type UserProvider interface {
+	Insert(ctx context.Context, user *model.User) (*model.User, error)
+	GetOne(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	Update(ctx context.Context, user *model.User) (bool, error)
+}
+
+type UserRepository struct {
+	DB *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	repo := new(UserRepository)
+	repo.DB = db
+	return repo
+}
+
+func (repo *UserRepository) Insert(ctx context.Context, user *model.User) (*model.User, error) {
+	op := "repository.UserRepository.Insert"
+	if err := gorm.G[model.User](repo.DB).Create(ctx, user); err != nil {
+		if util.CheckDublicateErr(err) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "Duplicated email when create user"),
+				zap.Object("User", user))
+			return nil, &service_errors.DuplicateError{}
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when create user"),
+			zap.Object("User", user))
+		return nil, err
+	}
+	common.Log(ctx).Info("DB success",
+		zap.String("op", op),
+		zap.String("Result", "User created successfully!"),
+		zap.Object("User", user))
+	return user, nil
+}
+
+func (repo *UserRepository) GetOne(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	op := "repository.UserRepository.GetOne"
+	user, err := gorm.G[model.User](repo.DB).Where("id = ?", userID).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "User not found"),
+				zap.String("user_id", userID.String()))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find one user"),
+			zap.String("user_id", userID.String()))
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	op := "repository.UserRepository.GetByEmail"
+	user, err := gorm.G[model.User](repo.DB).Where("email = ?", email).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			common.Log(ctx).Warn("DB warn",
+				zap.String("op", op),
+				zap.String("Result", "User with email not found"))
+			return nil, nil
+		}
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when find user by email"))
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (repo *UserRepository) Update(ctx context.Context, user *model.User) (bool, error) {
+	op := "repository.UserRepository.Update"
+	rowsAffected, err := gorm.G[model.User](repo.DB).
+		Where("id = ?", user.ID).
+		Select("display_name", "password_hash").
+		Updates(ctx, *user)
+	if err != nil {
+		common.Log(ctx).Error("DB error",
+			zap.String("op", op),
+			zap.String("Result", "Error occured when update user"),
+			zap.Object("User", user))
+		return false, err
+	}
+	if rowsAffected == 0 {
+		common.Log(ctx).Warn("DB warn",
+			zap.String("op", op),
+			zap.String("Result", "Updatable user not found"),
+			zap.String("user_id", user.ID.String()))
+		return false, nil
+	}
+	common.Log(ctx).Info("DB info",
+		zap.String("op", op),
+		zap.String("Result", "User updated with success"),
+		zap.Object("User", user))
+	return true, nil
+}
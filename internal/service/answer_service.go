@@ -3,19 +3,31 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/mbilarusdev/quiz/internal/common"
 	"github.com/mbilarusdev/quiz/internal/model"
 	"github.com/mbilarusdev/quiz/internal/repository"
 	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type AnswerLogic interface {
-	AddAnswer(answer *model.Answer) (*model.Answer, error)
-	FindOne(answerID int) (*model.Answer, error)
-	Delete(answerID int) (bool, error)
+	AddAnswer(ctx context.Context, actor *model.Actor, answer *model.Answer) (*model.Answer, error)
+	// Update, Patch and Delete apply only to answer with given version,
+	// zero version matches any
+	Update(ctx context.Context, actor *model.Actor, answerID int, version int, answer *model.Answer) (*model.Answer, error)
+	Patch(ctx context.Context, actor *model.Actor, answerID int, version int, patch []byte) (*model.Answer, error)
+	FindOne(ctx context.Context, actor *model.Actor, answerID int) (*model.Answer, error)
+	Delete(ctx context.Context, actor *model.Actor, answerID int, version int) (bool, error)
+	FindTrash(ctx context.Context, actor *model.Actor, params *model.ListParams) (*model.Page[model.Answer], error)
+	Restore(ctx context.Context, actor *model.Actor, answerID int) (*model.Answer, error)
+	Purge(ctx context.Context, actor *model.Actor, answerID int, version int) (bool, error)
+	FindByQuestion(ctx context.Context, actor *model.Actor, questionID int, params *model.ListParams) (*model.Page[model.Answer], error)
+	FindByUser(ctx context.Context, actor *model.Actor, userID uuid.UUID, params *model.ListParams) (*model.Page[model.Answer], error)
 }
 
 type AnswerService struct {
@@ -36,33 +48,62 @@ func NewAnswerService(
 	return srv
 }
 
-func (service *AnswerService) AddAnswer(answer *model.Answer) (*model.Answer, error) {
+func (service *AnswerService) AddAnswer(ctx context.Context, actor *model.Actor, answer *model.Answer) (*model.Answer, error) {
 	op := "service.AnswerService.AddAnswer"
-	ctx := context.Background()
+	if err := authorize(ctx, actor, PostAnswers); err != nil {
+		return nil, err
+	}
+	if answer.UserID != actor.UserID {
+		if err := authorize(ctx, actor, ManageAnswers); err != nil {
+			return nil, err
+		}
+	}
+	// Answer key is part of question, so only question managers may set it
+	if answer.IsCorrect || answer.Position != nil {
+		if err := authorize(ctx, actor, ManageQuestions); err != nil {
+			return nil, err
+		}
+	}
+	answer.Version = 0
 	var newAnswer *model.Answer
 
-	err := service.DB.Transaction(func(tx *gorm.DB) error {
-		question, err := service.QuestionRepo.GetOne(tx, ctx, answer.QuestionID, false)
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		question, err := service.QuestionRepo.GetOne(tx, ctx, answer.QuestionID, true)
 		if err != nil {
-			common.L.Error("Domain error",
+			common.Log(ctx).Error("Domain error",
 				zap.String("op", op),
 				zap.String("Result", "Error when try to find question which needed add answer"))
 			return err
 		}
 		if question == nil {
-			common.L.Warn("Domain warn",
+			common.Log(ctx).Warn("Domain warn",
 				zap.String("op", op),
 				zap.String("Result", "Question which needed to add answer not found"))
 			return &service_errors.NotFoundError{ID: answer.QuestionID}
 		}
+		grader, err := GraderFor(question.Type)
+		if err != nil {
+			return err
+		}
+		if err := grader.ValidateAnswer(question, answer); err != nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Answer is not valid for question type"),
+				zap.String("type", question.Type),
+				zap.Error(err))
+			return err
+		}
 
 		newAnswer, err = service.AnswerRepo.Insert(tx, ctx, answer)
 		if err != nil {
-			common.L.Error("Domain error",
+			common.Log(ctx).Error("Domain error",
 				zap.String("op", op),
 				zap.String("Result", "Error occured when inserting answer"))
 			return err
 		}
+		if err := service.QuestionRepo.Touch(tx, ctx, question.ID); err != nil {
+			return err
+		}
 
 		return nil
 	}, &sql.TxOptions{
@@ -73,7 +114,8 @@ func (service *AnswerService) AddAnswer(answer *model.Answer) (*model.Answer, er
 		return nil, err
 	}
 
-	common.L.Info("Domain info",
+	hideAnswerKey(actor, newAnswer)
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
 		zap.String("Result", "Answer added successfully"),
 		zap.Int("id", newAnswer.ID),
@@ -81,49 +123,504 @@ func (service *AnswerService) AddAnswer(answer *model.Answer) (*model.Answer, er
 	return newAnswer, nil
 }
 
-func (service *AnswerService) FindOne(answerID int) (*model.Answer, error) {
+func (service *AnswerService) Update(ctx context.Context, actor *model.Actor, answerID int, version int, answer *model.Answer) (*model.Answer, error) {
+	op := "service.AnswerService.Update"
+	return service.update(ctx, op, actor, answerID, version, func(_ *model.Answer) (*model.Answer, error) {
+		return answer, nil
+	})
+}
+
+func (service *AnswerService) Patch(ctx context.Context, actor *model.Actor, answerID int, version int, patch []byte) (*model.Answer, error) {
+	op := "service.AnswerService.Patch"
+	return service.update(ctx, op, actor, answerID, version, func(existing *model.Answer) (*model.Answer, error) {
+		jsonAnswer, err := json.Marshal(existing)
+		if err != nil {
+			return nil, err
+		}
+		patched, err := util.MergePatch(jsonAnswer, patch)
+		if err != nil {
+			return nil, &service_errors.ValidationError{Field: "body", Reason: "Merge patch is not a valid JSON"}
+		}
+		answer := &model.Answer{}
+		if err := json.Unmarshal(patched, answer); err != nil {
+			return nil, &service_errors.ValidationError{Field: "body", Reason: "Patched 'Answer' is not valid"}
+		}
+		return answer, nil
+	})
+}
+
+// update replaces editable fields of answer with ones built by change from
+// existing answer and validates result against question of the answer.
+func (service *AnswerService) update(
+	ctx context.Context,
+	op string,
+	actor *model.Actor,
+	answerID int,
+	version int,
+	change func(existing *model.Answer) (*model.Answer, error),
+) (*model.Answer, error) {
+	var updated *model.Answer
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := service.AnswerRepo.GetOne(tx, ctx, answerID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find answer to update"),
+				zap.Int("id", answerID))
+			return err
+		}
+		if existing == nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Answer to update not found!"),
+				zap.Int("id", answerID))
+			return &service_errors.NotFoundError{ID: answerID, Entity: "Answer"}
+		}
+		if err := authorizeOwned(ctx, actor, ManageAnswers, existing.UserID); err != nil {
+			return err
+		}
+		if version != 0 && existing.Version != version {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Answer to update has another version"),
+				zap.Int("id", answerID),
+				zap.Int("version", version),
+				zap.Int("current_version", existing.Version))
+			return &service_errors.ConflictError{ID: answerID, Entity: "Answer", Version: existing.Version}
+		}
+		questionID, userID, currentVersion := existing.QuestionID, existing.UserID, existing.Version
+		isCorrect, position := existing.IsCorrect, positionOf(*existing)
+
+		answer, err := change(existing)
+		if err != nil {
+			return err
+		}
+		if answer.ID != 0 && answer.ID != answerID {
+			return &service_errors.ValidationError{Field: "id", Reason: "Field 'id' can't be changed"}
+		}
+		if answer.QuestionID != 0 && answer.QuestionID != questionID {
+			return &service_errors.ValidationError{Field: "question_id", Reason: "Field 'question_id' can't be changed"}
+		}
+		if answer.UserID != uuid.Nil && answer.UserID != userID {
+			return &service_errors.ValidationError{Field: "user_id", Reason: "Field 'user_id' can't be changed"}
+		}
+		if answer.IsCorrect != isCorrect || positionOf(*answer) != position {
+			if err := authorize(ctx, actor, ManageQuestions); err != nil {
+				return err
+			}
+		}
+		answer.ID, answer.QuestionID, answer.UserID = answerID, questionID, userID
+		answer.Version = currentVersion
+		if err := validateText(answer.Text); err != nil {
+			return err
+		}
+
+		question, err := service.QuestionRepo.GetOne(tx, ctx, questionID, true)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find question of answer"),
+				zap.Int("question_id", questionID))
+			return err
+		}
+		if question == nil {
+			return &service_errors.NotFoundError{ID: questionID, Entity: "Question"}
+		}
+		grader, err := GraderFor(question.Type)
+		if err != nil {
+			return err
+		}
+		for i := range question.Answers {
+			if question.Answers[i].ID == answerID {
+				question.Answers = without(question.Answers, i)
+				break
+			}
+		}
+		if err := grader.ValidateAnswer(question, answer); err != nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Answer is not valid for question type"),
+				zap.String("type", question.Type),
+				zap.Error(err))
+			return err
+		}
+
+		updatedRow, err := service.AnswerRepo.Update(tx, ctx, answer)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to update answer"),
+				zap.Int("id", answerID))
+			return err
+		}
+		if !updatedRow {
+			return service.conflictOf(tx, ctx, answerID)
+		}
+		if err := service.QuestionRepo.Touch(tx, ctx, questionID); err != nil {
+			return err
+		}
+		updated, err = service.AnswerRepo.GetOne(tx, ctx, answerID)
+		return err
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	hideAnswerKey(actor, updated)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Answer updated successfully"),
+		zap.Int("id", answerID))
+	return updated, nil
+}
+
+func (service *AnswerService) FindOne(ctx context.Context, actor *model.Actor, answerID int) (*model.Answer, error) {
 	op := "service.AnswerService.AddAnswer"
-	answer, err := service.AnswerRepo.GetOne(context.Background(), answerID)
+	if err := authorize(ctx, actor, ReadAnswers); err != nil {
+		return nil, err
+	}
+	answer, err := service.AnswerRepo.GetOne(nil, ctx, answerID)
 	if err != nil {
-		common.L.Info("Domain error",
+		common.Log(ctx).Info("Domain error",
 			zap.String("op", op),
 			zap.String("Result", "Error when try to find answer"),
 			zap.Int("id", answerID))
 		return nil, err
 	}
 	if answer == nil {
-		common.L.Warn("Domain warn",
+		common.Log(ctx).Warn("Domain warn",
 			zap.String("op", op),
 			zap.String("Result", "Answer not found"),
 			zap.Int("id", answerID))
 		return answer, &service_errors.NotFoundError{ID: answerID}
 	}
-	common.L.Info("Domain info",
+	if !allowed(actor, ManageQuestions) {
+		question, err := service.QuestionRepo.GetOne(nil, ctx, answer.QuestionID, false)
+		if err != nil {
+			return nil, err
+		}
+		if question != nil && model.KeyInText(question.Type) {
+			if err := authorize(ctx, actor, ManageQuestions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	hideAnswerKey(actor, answer)
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
 		zap.String("Result", "Answer finded successfully!"),
 		zap.Int("id", answerID))
 	return answer, nil
 }
 
-func (service *AnswerService) Delete(answerID int) (bool, error) {
+func (service *AnswerService) Delete(ctx context.Context, actor *model.Actor, answerID int, version int) (bool, error) {
 	op := "service.AnswerService.Delete"
-	deleted, err := service.AnswerRepo.Delete(context.Background(), answerID)
+	var deleted bool
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answer, err := service.AnswerRepo.GetOne(tx, ctx, answerID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find answer to delete"),
+				zap.Int("id", answerID))
+			return err
+		}
+		if answer == nil {
+			return nil
+		}
+		if err := authorizeOwned(ctx, actor, ManageAnswers, answer.UserID); err != nil {
+			return err
+		}
+		deleted, err = service.AnswerRepo.Delete(tx, ctx, answerID, version)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to delete answer"),
+				zap.Int("id", answerID))
+			return err
+		}
+		if !deleted {
+			err = service.conflictOf(tx, ctx, answerID)
+			if _, ok := err.(*service_errors.NotFoundError); ok {
+				return nil
+			}
+			return err
+		}
+		return service.QuestionRepo.Touch(tx, ctx, answer.QuestionID)
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
 	if err != nil {
-		common.L.Error("Domain error",
-			zap.String("op", op),
-			zap.String("Result", "Error when try to delete answer"),
-			zap.Int("id", answerID))
 		return false, err
 	}
 	if !deleted {
-		common.L.Warn("Domain warn",
+		common.Log(ctx).Warn("Domain warn",
 			zap.String("op", op),
 			zap.String("Result", "Answer to delete not found!"),
 			zap.Int("id", answerID))
+		return false, nil
 	}
-	common.L.Info("Domain info",
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
 		zap.String("Result", "Answer deleted successfully!"),
 		zap.Int("id", answerID))
 	return deleted, nil
 }
+
+func (service *AnswerService) FindTrash(ctx context.Context, actor *model.Actor, params *model.ListParams) (*model.Page[model.Answer], error) {
+	op := "service.AnswerService.FindTrash"
+	if err := authorize(ctx, actor, ManageAnswers); err != nil {
+		return nil, err
+	}
+	if err := prepareListParams(params); err != nil {
+		return nil, err
+	}
+	answers, total, err := service.AnswerRepo.GetTrash(ctx, params)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find page of deleted answers"))
+		return nil, err
+	}
+	page, err := buildPage(answers, total, params, answerCursor)
+	if err != nil {
+		return nil, err
+	}
+	hideAnswersKey(actor, page.Items)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Page of deleted answers finded successfully"),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
+
+func (service *AnswerService) Restore(ctx context.Context, actor *model.Actor, answerID int) (*model.Answer, error) {
+	op := "service.AnswerService.Restore"
+	if err := authorize(ctx, actor, ManageAnswers); err != nil {
+		return nil, err
+	}
+	var restored *model.Answer
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted, err := service.AnswerRepo.GetDeleted(tx, ctx, answerID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find deleted answer"),
+				zap.Int("id", answerID))
+			return err
+		}
+		if deleted == nil {
+			active, err := service.AnswerRepo.GetOne(tx, ctx, answerID)
+			if err != nil {
+				return err
+			}
+			if active != nil {
+				return &service_errors.StateError{ID: answerID, State: "active", Action: "restored"}
+			}
+			return &service_errors.NotFoundError{ID: answerID, Entity: "Answer"}
+		}
+		question, err := service.QuestionRepo.GetOne(tx, ctx, deleted.QuestionID, false)
+		if err != nil {
+			return err
+		}
+		if question == nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Question of answer to restore is deleted"),
+				zap.Int("id", answerID),
+				zap.Int("question_id", deleted.QuestionID))
+			return &service_errors.StateError{ID: answerID, State: "orphaned", Action: "restored"}
+		}
+		if _, err := service.AnswerRepo.Restore(tx, ctx, answerID); err != nil {
+			return err
+		}
+		if err := service.QuestionRepo.Touch(tx, ctx, question.ID); err != nil {
+			return err
+		}
+		restored, err = service.AnswerRepo.GetOne(tx, ctx, answerID)
+		return err
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	hideAnswerKey(actor, restored)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Answer restored successfully!"),
+		zap.Int("id", answerID))
+	return restored, nil
+}
+
+func (service *AnswerService) Purge(ctx context.Context, actor *model.Actor, answerID int, version int) (bool, error) {
+	op := "service.AnswerService.Purge"
+	if err := authorize(ctx, actor, PurgeAnswers); err != nil {
+		return false, err
+	}
+	var purged bool
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answer, err := service.AnswerRepo.GetOne(tx, ctx, answerID)
+		if err != nil {
+			return err
+		}
+		if answer == nil {
+			answer, err = service.AnswerRepo.GetDeleted(tx, ctx, answerID)
+			if err != nil {
+				return err
+			}
+		}
+		if answer == nil {
+			return nil
+		}
+		purged, err = service.AnswerRepo.Purge(tx, ctx, answerID, version)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to purge answer"),
+				zap.Int("id", answerID))
+			return err
+		}
+		if !purged {
+			return &service_errors.ConflictError{ID: answerID, Entity: "Answer", Version: answer.Version}
+		}
+		if answer.DeletedAt.Valid {
+			return nil
+		}
+		return service.QuestionRepo.Touch(tx, ctx, answer.QuestionID)
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return false, err
+	}
+	if !purged {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Answer to purge not found!"),
+			zap.Int("id", answerID))
+		return false, nil
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Answer purged successfully!"),
+		zap.Int("id", answerID))
+	return true, nil
+}
+
+// conflictOf explains why answer with expected version was not changed,
+// it returns ConflictError when answer exists and NotFoundError otherwise.
+func (service *AnswerService) conflictOf(tx *gorm.DB, ctx context.Context, answerID int) error {
+	current, err := service.AnswerRepo.GetOne(tx, ctx, answerID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return &service_errors.NotFoundError{ID: answerID, Entity: "Answer"}
+	}
+	return &service_errors.ConflictError{ID: answerID, Entity: "Answer", Version: current.Version}
+}
+
+func (service *AnswerService) FindByQuestion(
+	ctx context.Context,
+	actor *model.Actor,
+	questionID int,
+	params *model.ListParams,
+) (*model.Page[model.Answer], error) {
+	op := "service.AnswerService.FindByQuestion"
+	if err := authorize(ctx, actor, ReadAnswers); err != nil {
+		return nil, err
+	}
+	if err := prepareListParams(params); err != nil {
+		return nil, err
+	}
+	question, err := service.QuestionRepo.GetOne(nil, ctx, questionID, false)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find question of answers"),
+			zap.Int("question_id", questionID))
+		return nil, err
+	}
+	if question == nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Question of answers not found"),
+			zap.Int("question_id", questionID))
+		return nil, &service_errors.NotFoundError{ID: questionID, Entity: "Question"}
+	}
+	if model.KeyInText(question.Type) {
+		if err := authorize(ctx, actor, ManageQuestions); err != nil {
+			return nil, err
+		}
+	}
+	answers, total, err := service.AnswerRepo.GetByQuestion(ctx, questionID, params)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find page of question answers"),
+			zap.Int("question_id", questionID))
+		return nil, err
+	}
+	page, err := buildPage(answers, total, params, answerCursor)
+	if err != nil {
+		return nil, err
+	}
+	hideAnswersKey(actor, page.Items)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Page of question answers finded successfully"),
+		zap.Int("question_id", questionID),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
+
+func (service *AnswerService) FindByUser(
+	ctx context.Context,
+	actor *model.Actor,
+	userID uuid.UUID,
+	params *model.ListParams,
+) (*model.Page[model.Answer], error) {
+	op := "service.AnswerService.FindByUser"
+	if err := authorize(ctx, actor, ReadAnswers); err != nil {
+		return nil, err
+	}
+	if err := prepareListParams(params); err != nil {
+		return nil, err
+	}
+	withKeyInText := allowed(actor, ManageQuestions)
+	answers, total, err := service.AnswerRepo.GetByUser(ctx, userID, params, withKeyInText)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find page of user answers"),
+			zap.String("user_id", userID.String()))
+		return nil, err
+	}
+	page, err := buildPage(answers, total, params, answerCursor)
+	if err != nil {
+		return nil, err
+	}
+	hideAnswersKey(actor, page.Items)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Page of user answers finded successfully"),
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
+
+func answerCursor(answer model.Answer) model.Cursor {
+	return model.Cursor{ID: answer.ID, CreatedAt: answer.CreatedAt}
+}
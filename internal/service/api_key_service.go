@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	apiKeyPrefix       = "qk_"
+	apiKeyPrefixLength = len(apiKeyPrefix) + 8
+)
+
+type APIKeyLogic interface {
+	// Issue creates key and returns it with plain Key, which is not stored
+	Issue(ctx context.Context, actor *model.Actor, key *model.APIKey) (*model.APIKey, error)
+	FindAll(ctx context.Context, actor *model.Actor) ([]model.APIKey, error)
+	Revoke(ctx context.Context, actor *model.Actor, keyID int) (bool, error)
+	// Authenticate returns actor of active key and records its usage
+	Authenticate(ctx context.Context, rawKey string) (*model.Actor, error)
+}
+
+type APIKeyService struct {
+	APIKeyRepo repository.APIKeyProvider
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyProvider) *APIKeyService {
+	service := new(APIKeyService)
+	service.APIKeyRepo = apiKeyRepo
+	return service
+}
+
+func (service *APIKeyService) Issue(ctx context.Context, actor *model.Actor, key *model.APIKey) (*model.APIKey, error) {
+	op := "service.APIKeyService.Issue"
+	if err := authorize(ctx, actor, ManageAPIKeys); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(key.Name) == "" {
+		return nil, &service_errors.ValidationError{Field: "name", Reason: "Field 'name' must not be empty"}
+	}
+	if len(key.Scopes) == 0 {
+		return nil, &service_errors.ValidationError{Field: "scopes", Reason: "At least one scope is required"}
+	}
+	for _, scope := range key.Scopes {
+		if _, ok := scopePermissions[scope]; !ok {
+			return nil, &service_errors.ValidationError{Field: "scopes", Reason: "Unknown scope '" + scope + "'"}
+		}
+	}
+	slices.Sort(key.Scopes)
+	key.Scopes = slices.Compact(key.Scopes)
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return nil, &service_errors.ValidationError{Field: "expires_at", Reason: "Field 'expires_at' must be in future"}
+	}
+	if key.UserID == uuid.Nil {
+		key.UserID = actor.UserID
+	}
+
+	rawKey, err := generateAPIKey()
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to generate api key"))
+		return nil, err
+	}
+	key.ID = 0
+	key.Prefix = rawKey[:apiKeyPrefixLength]
+	key.KeyHash = hashAPIKey(rawKey)
+	key.LastUsedAt, key.RevokedAt = nil, nil
+
+	key, err = service.APIKeyRepo.Insert(ctx, key)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to create api key"))
+		return nil, err
+	}
+	key.Key = rawKey
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "API key issued with success"),
+		zap.Int("id", key.ID),
+		zap.Object("Actor", actor))
+	return key, nil
+}
+
+func (service *APIKeyService) FindAll(ctx context.Context, actor *model.Actor) ([]model.APIKey, error) {
+	op := "service.APIKeyService.FindAll"
+	if err := authorize(ctx, actor, ManageAPIKeys); err != nil {
+		return nil, err
+	}
+	keys, err := service.APIKeyRepo.GetAll(ctx)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find api keys"))
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (service *APIKeyService) Revoke(ctx context.Context, actor *model.Actor, keyID int) (bool, error) {
+	op := "service.APIKeyService.Revoke"
+	if err := authorize(ctx, actor, ManageAPIKeys); err != nil {
+		return false, err
+	}
+	revoked, err := service.APIKeyRepo.Revoke(ctx, keyID, time.Now())
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to revoke api key"),
+			zap.Int("id", keyID))
+		return false, err
+	}
+	if !revoked {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "API key to revoke not found!"),
+			zap.Int("id", keyID))
+		return false, nil
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "API key revoked with success"),
+		zap.Int("id", keyID),
+		zap.Object("Actor", actor))
+	return true, nil
+}
+
+func (service *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*model.Actor, error) {
+	op := "service.APIKeyService.Authenticate"
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, &service_errors.NotFoundError{Entity: "APIKey"}
+	}
+	key, err := service.APIKeyRepo.GetByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, &service_errors.NotFoundError{Entity: "APIKey"}
+	}
+	now := time.Now()
+	if key.RevokedAt != nil {
+		return nil, &service_errors.StateError{ID: key.ID, State: "revoked", Action: "used"}
+	}
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return nil, &service_errors.StateError{ID: key.ID, State: "expired", Action: "used"}
+	}
+	if err := service.APIKeyRepo.SetLastUsed(ctx, key.ID, now); err != nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Failed to record usage of api key"),
+			zap.Int("id", key.ID),
+			zap.Error(err))
+	}
+	return &model.Actor{
+		UserID:   key.UserID,
+		Role:     model.RoleAPIKey,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashAPIKey returns SHA-256 of key, keys are random enough to not need salt.
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
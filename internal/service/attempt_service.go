@@ -0,0 +1,561 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/metrics"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// expiredBatchSize limits how many expired attempts are closed per sweep.
+const expiredBatchSize = 100
+
+type AttemptLogic interface {
+	Start(ctx context.Context, quizID int, attempt *model.Attempt) (*model.Attempt, error)
+	FindOne(ctx context.Context, actor *model.Actor, attemptID int) (*model.Attempt, error)
+	Respond(ctx context.Context, actor *model.Actor, attemptID int, response *model.AttemptResponse) (*model.AttemptResponse, error)
+	Finish(ctx context.Context, actor *model.Actor, attemptID int) (*model.Attempt, error)
+	Abandon(ctx context.Context, actor *model.Actor, attemptID int) (*model.Attempt, error)
+	Next(ctx context.Context, actor *model.Actor, attemptID int) (*model.Question, error)
+	CloseExpired(ctx context.Context) (int, error)
+}
+
+type AttemptService struct {
+	AttemptRepo  repository.AttemptProvider
+	QuizRepo     repository.QuizProvider
+	QuestionRepo repository.QuestionProvider
+	DB           *gorm.DB
+}
+
+func NewAttemptService(
+	attemptRepo repository.AttemptProvider,
+	quizRepo repository.QuizProvider,
+	questionRepo repository.QuestionProvider,
+	db *gorm.DB,
+) *AttemptService {
+	srv := new(AttemptService)
+	srv.AttemptRepo = attemptRepo
+	srv.QuizRepo = quizRepo
+	srv.QuestionRepo = questionRepo
+	srv.DB = db
+	return srv
+}
+
+func (service *AttemptService) Start(ctx context.Context, quizID int, attempt *model.Attempt) (*model.Attempt, error) {
+	op := "service.AttemptService.Start"
+	var newAttempt *model.Attempt
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		quiz, err := service.QuizRepo.GetOne(tx, ctx, quizID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find quiz which needed to start attempt"),
+				zap.Int("quiz_id", quizID))
+			return err
+		}
+		if quiz == nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Quiz which needed to start attempt not found"),
+				zap.Int("quiz_id", quizID))
+			return &service_errors.NotFoundError{ID: quizID, Entity: "Quiz"}
+		}
+		// Max score is fixed at start, so editing quiz doesn't change score
+		// of attempts in progress
+		questions, err := service.QuizRepo.GetQuestions(tx, ctx, quizID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find questions of quiz which needed to start attempt"),
+				zap.Int("quiz_id", quizID))
+			return err
+		}
+
+		attempt.ID = 0
+		attempt.QuizID = quizID
+		attempt.Status = model.AttemptStarted
+		attempt.Score = 0
+		attempt.MaxScore = float64(len(questions))
+		attempt.StartedAt = time.Now()
+		attempt.FinishedAt = nil
+		attempt.ExpiresAt = nil
+		attempt.CurrentQuestionID = nil
+		attempt.QuestionStartedAt = nil
+		if quiz.TimeLimit != nil {
+			expiresAt := attempt.StartedAt.Add(time.Duration(*quiz.TimeLimit) * time.Second)
+			attempt.ExpiresAt = &expiresAt
+		}
+		newAttempt, err = service.AttemptRepo.Insert(tx, ctx, attempt)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error occured when inserting attempt"),
+				zap.Int("quiz_id", quizID))
+			return err
+		}
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Attempt started successfully"),
+		zap.Int("id", newAttempt.ID),
+		zap.Int("quiz_id", quizID))
+	return newAttempt, nil
+}
+
+func (service *AttemptService) FindOne(ctx context.Context, actor *model.Actor, attemptID int) (*model.Attempt, error) {
+	op := "service.AttemptService.FindOne"
+	attempt, err := service.AttemptRepo.GetOne(ctx, attemptID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find attempt"),
+			zap.Int("id", attemptID))
+		return nil, err
+	}
+	if attempt == nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Attempt not found"),
+			zap.Int("id", attemptID))
+		return nil, &service_errors.NotFoundError{ID: attemptID, Entity: "Attempt"}
+	}
+	if err := authorizeOwned(ctx, actor, ManageAttempts, attempt.UserID); err != nil {
+		return nil, err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Attempt finded successfully!"),
+		zap.Int("id", attemptID))
+	return attempt, nil
+}
+
+func (service *AttemptService) Respond(
+	ctx context.Context,
+	actor *model.Actor,
+	attemptID int,
+	response *model.AttemptResponse,
+) (*model.AttemptResponse, error) {
+	op := "service.AttemptService.Respond"
+	var newResponse *model.AttemptResponse
+	var answeredType string
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attempt, err := service.lockActive(tx, ctx, actor, attemptID, "answered")
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if attempt.ExpiresAt != nil && now.After(*attempt.ExpiresAt) {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Attempt deadline has passed"),
+				zap.Int("id", attemptID))
+			return &service_errors.DeadlineError{ID: attemptID, Deadline: *attempt.ExpiresAt}
+		}
+
+		questions, err := service.QuizRepo.GetQuestions(tx, ctx, attempt.QuizID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find questions of attempt quiz"),
+				zap.Int("id", attemptID))
+			return err
+		}
+		if !containsQuestion(questions, response.QuestionID) {
+			return &service_errors.ValidationError{
+				Field: "question_id",
+				Reason: fmt.Sprintf(
+					"'Question' with id=%v doesn't belong to 'Quiz' with id=%v",
+					response.QuestionID,
+					attempt.QuizID,
+				),
+			}
+		}
+
+		question, err := service.QuestionRepo.GetOne(tx, ctx, response.QuestionID, true)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find answered question"),
+				zap.Int("id", attemptID),
+				zap.Int("question_id", response.QuestionID))
+			return err
+		}
+		if question == nil {
+			return &service_errors.NotFoundError{ID: response.QuestionID, Entity: "Question"}
+		}
+		if question.TimeLimit != nil {
+			if attempt.CurrentQuestionID == nil || *attempt.CurrentQuestionID != question.ID {
+				return &service_errors.ValidationError{
+					Field: "question_id",
+					Reason: fmt.Sprintf(
+						"Timed 'Question' with id=%v must be served by next before answering",
+						question.ID,
+					),
+				}
+			}
+			deadline := attempt.QuestionStartedAt.Add(time.Duration(*question.TimeLimit) * time.Second)
+			if now.After(deadline) {
+				common.Log(ctx).Warn("Domain warn",
+					zap.String("op", op),
+					zap.String("Result", "Question deadline has passed"),
+					zap.Int("id", attemptID),
+					zap.Int("question_id", question.ID))
+				return &service_errors.DeadlineError{ID: question.ID, Deadline: deadline}
+			}
+		}
+		grade, err := gradeQuestion(question, &response.Choice)
+		if err != nil {
+			return err
+		}
+		response.IsCorrect = grade.Correct
+		response.Score = grade.Score
+		answeredType = questionType(question)
+
+		response.ID = 0
+		response.AttemptID = attemptID
+		newResponse, err = service.AttemptRepo.InsertResponse(tx, ctx, response)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error occured when inserting attempt response"),
+				zap.Int("id", attemptID))
+			return err
+		}
+
+		if attempt.Status == model.AttemptStarted {
+			attempt.Status = model.AttemptInProgress
+			return service.AttemptRepo.UpdateState(tx, ctx, attempt)
+		}
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObserveGrade(metrics.SourceAttempt, answeredType, newResponse.IsCorrect, newResponse.Score)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Attempt response added successfully"),
+		zap.Int("id", attemptID),
+		zap.Int("question_id", newResponse.QuestionID),
+		zap.Bool("is_correct", newResponse.IsCorrect),
+		zap.Float64("score", newResponse.Score))
+	return newResponse, nil
+}
+
+func (service *AttemptService) Finish(ctx context.Context, actor *model.Actor, attemptID int) (*model.Attempt, error) {
+	op := "service.AttemptService.Finish"
+	var finished *model.Attempt
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attempt, err := service.lockActive(tx, ctx, actor, attemptID, "finished")
+		if err != nil {
+			return err
+		}
+		// Attempt finished after its deadline is closed the same way as sweeper does
+		status, finishedAt := model.AttemptFinished, time.Now()
+		if attempt.ExpiresAt != nil && finishedAt.After(*attempt.ExpiresAt) {
+			status, finishedAt = model.AttemptExpired, *attempt.ExpiresAt
+		}
+		if err := service.close(tx, ctx, attempt, status, finishedAt); err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to close attempt"),
+				zap.Int("id", attemptID))
+			return err
+		}
+		finished = attempt
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Attempt finished successfully"),
+		zap.Int("id", attemptID),
+		zap.String("status", finished.Status),
+		zap.Float64("score", finished.Score),
+		zap.Float64("max_score", finished.MaxScore))
+	return finished, nil
+}
+
+func (service *AttemptService) Abandon(ctx context.Context, actor *model.Actor, attemptID int) (*model.Attempt, error) {
+	op := "service.AttemptService.Abandon"
+	var abandoned *model.Attempt
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attempt, err := service.lockActive(tx, ctx, actor, attemptID, "abandoned")
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		attempt.Status = model.AttemptAbandoned
+		attempt.FinishedAt = &now
+		if err := service.AttemptRepo.UpdateState(tx, ctx, attempt); err != nil {
+			return err
+		}
+		abandoned = attempt
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Attempt abandoned successfully"),
+		zap.Int("id", attemptID))
+	return abandoned, nil
+}
+
+func (service *AttemptService) Next(ctx context.Context, actor *model.Actor, attemptID int) (*model.Question, error) {
+	op := "service.AttemptService.Next"
+	var next *model.Question
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attempt, err := service.lockActive(tx, ctx, actor, attemptID, "served")
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if attempt.ExpiresAt != nil && now.After(*attempt.ExpiresAt) {
+			return &service_errors.DeadlineError{ID: attemptID, Deadline: *attempt.ExpiresAt}
+		}
+		questions, err := service.QuizRepo.GetQuestions(tx, ctx, attempt.QuizID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find questions of attempt quiz"),
+				zap.Int("id", attemptID))
+			return err
+		}
+		responses, err := service.AttemptRepo.GetResponses(tx, ctx, attemptID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find responses of attempt"),
+				zap.Int("id", attemptID))
+			return err
+		}
+		answered := make(map[int]bool, len(responses))
+		for _, response := range responses {
+			answered[response.QuestionID] = true
+		}
+
+		// Current question is served again until it is answered or timed out,
+		// after that the first unanswered question following it is served.
+		from := 0
+		if attempt.CurrentQuestionID != nil {
+			for i, question := range questions {
+				if question.ID != *attempt.CurrentQuestionID {
+					continue
+				}
+				timedOut := question.TimeLimit != nil &&
+					now.After(attempt.QuestionStartedAt.Add(time.Duration(*question.TimeLimit)*time.Second))
+				if !answered[question.ID] && !timedOut {
+					next, err = service.QuestionRepo.GetOne(tx, ctx, question.ID, true)
+					return err
+				}
+				from = i + 1
+				break
+			}
+		}
+		for _, question := range questions[from:] {
+			if answered[question.ID] {
+				continue
+			}
+			next, err = service.QuestionRepo.GetOne(tx, ctx, question.ID, true)
+			if err != nil {
+				return err
+			}
+			attempt.CurrentQuestionID = &question.ID
+			attempt.QuestionStartedAt = &now
+			return service.AttemptRepo.UpdateState(tx, ctx, attempt)
+		}
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	if next == nil {
+		common.Log(ctx).Info("Domain info",
+			zap.String("op", op),
+			zap.String("Result", "Attempt has no more questions to serve"),
+			zap.Int("id", attemptID))
+		return nil, nil
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Next question of attempt served successfully"),
+		zap.Int("id", attemptID),
+		zap.Int("question_id", next.ID))
+	return next, nil
+}
+
+func (service *AttemptService) CloseExpired(ctx context.Context) (int, error) {
+	op := "service.AttemptService.CloseExpired"
+	now := time.Now()
+	ids, err := service.AttemptRepo.GetExpiredIDs(ctx, now, expiredBatchSize)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find expired attempts"))
+		return 0, err
+	}
+
+	closed := 0
+	var firstErr error
+	for _, attemptID := range ids {
+		err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			attempt, err := service.AttemptRepo.GetOneForUpdate(tx, ctx, attemptID)
+			if err != nil {
+				return err
+			}
+			// Attempt could be finished between select and lock
+			if attempt == nil ||
+				(attempt.Status != model.AttemptStarted && attempt.Status != model.AttemptInProgress) ||
+				attempt.ExpiresAt == nil || !now.After(*attempt.ExpiresAt) {
+				return nil
+			}
+			if err := service.close(tx, ctx, attempt, model.AttemptExpired, *attempt.ExpiresAt); err != nil {
+				return err
+			}
+			closed++
+			return nil
+		}, &sql.TxOptions{
+			Isolation: sql.LevelReadCommitted,
+		})
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to close expired attempt"),
+				zap.Int("id", attemptID),
+				zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if closed > 0 {
+		common.Log(ctx).Info("Domain info",
+			zap.String("op", op),
+			zap.String("Result", "Expired attempts closed successfully"),
+			zap.Int("count", closed))
+	}
+	return closed, firstErr
+}
+
+// close scores attempt by its responses and moves it to final status, max
+// score stays as it was counted at start.
+func (service *AttemptService) close(
+	tx *gorm.DB,
+	ctx context.Context,
+	attempt *model.Attempt,
+	status string,
+	finishedAt time.Time,
+) error {
+	responses, err := service.AttemptRepo.GetResponses(tx, ctx, attempt.ID)
+	if err != nil {
+		return err
+	}
+	attempt.Status = status
+	attempt.Score = scoreOf(responses)
+	attempt.FinishedAt = &finishedAt
+	if err := service.AttemptRepo.UpdateState(tx, ctx, attempt); err != nil {
+		return err
+	}
+	attempt.Responses = responses
+	return nil
+}
+
+// lockActive locks attempt row inside tx and checks that attempt belongs to
+// actor and is still started or in progress, so it can be answered, finished
+// or abandoned.
+func (service *AttemptService) lockActive(
+	tx *gorm.DB,
+	ctx context.Context,
+	actor *model.Actor,
+	attemptID int,
+	action string,
+) (*model.Attempt, error) {
+	op := "service.AttemptService.lockActive"
+	attempt, err := service.AttemptRepo.GetOneForUpdate(tx, ctx, attemptID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to lock attempt"),
+			zap.Int("id", attemptID))
+		return nil, err
+	}
+	if attempt == nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Attempt not found"),
+			zap.Int("id", attemptID))
+		return nil, &service_errors.NotFoundError{ID: attemptID, Entity: "Attempt"}
+	}
+	if err := authorizeOwned(ctx, actor, ManageAttempts, attempt.UserID); err != nil {
+		return nil, err
+	}
+	if attempt.Status != model.AttemptStarted && attempt.Status != model.AttemptInProgress {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Attempt is already closed"),
+			zap.Int("id", attemptID),
+			zap.String("status", attempt.Status),
+			zap.String("action", action))
+		return nil, &service_errors.StateError{ID: attemptID, State: attempt.Status, Action: action}
+	}
+	return attempt, nil
+}
+
+func containsQuestion(questions []model.Question, questionID int) bool {
+	for _, question := range questions {
+		if question.ID == questionID {
+			return true
+		}
+	}
+	return false
+}
+
+func scoreOf(responses []model.AttemptResponse) float64 {
+	score := 0.0
+	for _, response := range responses {
+		score += response.Score
+	}
+	return score
+}
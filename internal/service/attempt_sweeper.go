@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"go.uber.org/zap"
+)
+
+// AttemptSweeper periodically closes attempts whose deadline has passed.
+type AttemptSweeper struct {
+	AttemptSrv AttemptLogic
+	Interval   time.Duration
+}
+
+func NewAttemptSweeper(attemptSrv AttemptLogic, interval time.Duration) *AttemptSweeper {
+	sweeper := new(AttemptSweeper)
+	sweeper.AttemptSrv = attemptSrv
+	sweeper.Interval = interval
+	return sweeper
+}
+
+// Run blocks until ctx is done.
+func (sweeper *AttemptSweeper) Run(ctx context.Context) {
+	op := "service.AttemptSweeper.Run"
+	ticker := time.NewTicker(sweeper.Interval)
+	defer ticker.Stop()
+	common.L.Info("Sweeper started",
+		zap.String("op", op),
+		zap.Duration("interval", sweeper.Interval))
+	for {
+		select {
+		case <-ctx.Done():
+			common.L.Info("Sweeper stopped", zap.String("op", op))
+			return
+		case <-ticker.C:
+			sweeper.sweep(ctx)
+		}
+	}
+}
+
+func (sweeper *AttemptSweeper) sweep(ctx context.Context) {
+	op := "service.AttemptSweeper.sweep"
+	defer func() {
+		if p := recover(); p != nil {
+			common.L.Error("Sweeper panic",
+				zap.String("op", op),
+				zap.Any("Panic", p))
+		}
+	}()
+	if _, err := sweeper.AttemptSrv.CloseExpired(ctx); err != nil {
+		common.L.Error("Sweeper error",
+			zap.String("op", op),
+			zap.Error(err))
+	}
+}
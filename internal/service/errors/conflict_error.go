@@ -0,0 +1,13 @@
+package service_errors
+
+import "fmt"
+
+type ConflictError struct {
+	ID      int
+	Entity  string
+	Version int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v with id=%v was modified, current version is %v\n", e.Entity, e.ID, e.Version)
+}
@@ -0,0 +1,7 @@
+package service_errors
+
+type CredentialsError struct{}
+
+func (e *CredentialsError) Error() string {
+	return "Invalid email or password\n"
+}
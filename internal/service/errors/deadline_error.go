@@ -0,0 +1,15 @@
+package service_errors
+
+import (
+	"fmt"
+	"time"
+)
+
+type DeadlineError struct {
+	ID       int
+	Deadline time.Time
+}
+
+func (e *DeadlineError) Error() string {
+	return fmt.Sprintf("Entity with id=%v deadline %v has passed\n", e.ID, e.Deadline.Format(time.RFC3339))
+}
@@ -0,0 +1,12 @@
+package service_errors
+
+import "fmt"
+
+type ForbiddenError struct {
+	Role   string
+	Action string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("Role '%v' is not allowed to %v\n", e.Role, e.Action)
+}
@@ -3,9 +3,13 @@ package service_errors
 import "fmt"
 
 type NotFoundError struct {
-	ID int
+	ID     int
+	Entity string
 }
 
 func (e *NotFoundError) Error() string {
+	if e.Entity != "" {
+		return fmt.Sprintf("%v with id=%v not found\n", e.Entity, e.ID)
+	}
 	return fmt.Sprintf("Entity with id=%v not found\n", e.ID)
 }
@@ -0,0 +1,13 @@
+package service_errors
+
+import "fmt"
+
+type StateError struct {
+	ID     int
+	State  string
+	Action string
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("Entity with id=%v in state '%v' can't be %v\n", e.ID, e.State, e.Action)
+}
@@ -0,0 +1,17 @@
+package service_errors
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UnknownUserError means that authenticated user has no account, so nothing
+// can reference it.
+type UnknownUserError struct {
+	UserID uuid.UUID
+}
+
+func (e *UnknownUserError) Error() string {
+	return fmt.Sprintf("'User' with id=%v has no account\n", e.UserID)
+}
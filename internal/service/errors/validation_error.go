@@ -0,0 +1,12 @@
+package service_errors
+
+import "fmt"
+
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Field '%v' is invalid: %v\n", e.Field, e.Reason)
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+)
+
+// Grader validates and grades questions of one type. New question types are
+// supported by registering their grader with RegisterGrader.
+type Grader interface {
+	// ValidateQuestion checks type specific settings of question.
+	ValidateQuestion(question *model.Question) error
+	// ValidateAnswer checks answer option before it is added to question.
+	ValidateAnswer(question *model.Question, answer *model.Answer) error
+	// Grade scores choice against question with preloaded answers,
+	// score is in range [0, 1].
+	Grade(question *model.Question, choice *model.Choice) (*model.Grade, error)
+}
+
+var graders = map[string]Grader{
+	model.SingleChoice:   singleChoiceGrader{},
+	model.MultipleChoice: multipleChoiceGrader{},
+	model.FreeText:       freeTextGrader{},
+	model.Numeric:        numericGrader{},
+	model.Ordering:       orderingGrader{},
+}
+
+func RegisterGrader(questionType string, grader Grader) {
+	graders[questionType] = grader
+}
+
+// GraderFor returns grader of question type, empty type means single choice.
+func GraderFor(questionType string) (Grader, error) {
+	if questionType == "" {
+		questionType = model.SingleChoice
+	}
+	grader, ok := graders[questionType]
+	if !ok {
+		return nil, &service_errors.ValidationError{
+			Field:  "type",
+			Reason: fmt.Sprintf("Question type '%v' is not supported", questionType),
+		}
+	}
+	return grader, nil
+}
+
+// questionType returns type of question, empty type means single choice.
+func questionType(question *model.Question) string {
+	if question.Type == "" {
+		return model.SingleChoice
+	}
+	return question.Type
+}
+
+func gradeQuestion(question *model.Question, choice *model.Choice) (*model.Grade, error) {
+	grader, err := GraderFor(question.Type)
+	if err != nil {
+		return nil, err
+	}
+	return grader.Grade(question, choice)
+}
+
+type singleChoiceGrader struct{}
+
+func (singleChoiceGrader) ValidateQuestion(question *model.Question) error {
+	return validateNoTolerance(question)
+}
+
+func (singleChoiceGrader) ValidateAnswer(_ *model.Question, _ *model.Answer) error {
+	return nil
+}
+
+func (singleChoiceGrader) Grade(question *model.Question, choice *model.Choice) (*model.Grade, error) {
+	if err := validateBelongs(question, []int{choice.AnswerID}, "answer_id"); err != nil {
+		return nil, err
+	}
+	grade := &model.Grade{CorrectAnswerIDs: correctAnswerIDs(question)}
+	grade.Correct = slices.Contains(grade.CorrectAnswerIDs, choice.AnswerID)
+	if grade.Correct {
+		grade.Score = 1
+	}
+	return grade, nil
+}
+
+type multipleChoiceGrader struct{}
+
+func (multipleChoiceGrader) ValidateQuestion(question *model.Question) error {
+	return validateNoTolerance(question)
+}
+
+func (multipleChoiceGrader) ValidateAnswer(_ *model.Question, _ *model.Answer) error {
+	return nil
+}
+
+// Grade requires all correct options to be chosen, with partial credit every
+// correct option adds and every wrong option takes away its share of score.
+func (multipleChoiceGrader) Grade(question *model.Question, choice *model.Choice) (*model.Grade, error) {
+	if len(choice.AnswerIDs) == 0 {
+		return nil, &service_errors.ValidationError{
+			Field:  "answer_ids",
+			Reason: "At least one answer must be chosen",
+		}
+	}
+	if err := validateBelongs(question, choice.AnswerIDs, "answer_ids"); err != nil {
+		return nil, err
+	}
+	grade := &model.Grade{CorrectAnswerIDs: correctAnswerIDs(question)}
+	hits, misses := 0, 0
+	for _, answerID := range choice.AnswerIDs {
+		if slices.Contains(grade.CorrectAnswerIDs, answerID) {
+			hits++
+		} else {
+			misses++
+		}
+	}
+	grade.Correct = misses == 0 && hits == len(grade.CorrectAnswerIDs)
+	if grade.Correct {
+		grade.Score = 1
+	} else if question.Partial && len(grade.CorrectAnswerIDs) > 0 {
+		grade.Score = math.Max(0, float64(hits-misses)/float64(len(grade.CorrectAnswerIDs)))
+	}
+	return grade, nil
+}
+
+type freeTextGrader struct{}
+
+func (freeTextGrader) ValidateQuestion(question *model.Question) error {
+	return validateNoTolerance(question)
+}
+
+func (freeTextGrader) ValidateAnswer(_ *model.Question, answer *model.Answer) error {
+	if normalizeText(answer.Text) == "" {
+		return &service_errors.ValidationError{
+			Field:  "text",
+			Reason: "Free text answer must contain letters or digits",
+		}
+	}
+	return nil
+}
+
+func (freeTextGrader) Grade(question *model.Question, choice *model.Choice) (*model.Grade, error) {
+	text := normalizeText(choice.Text)
+	if text == "" {
+		return nil, &service_errors.ValidationError{
+			Field:  "text",
+			Reason: "Answer text must not be empty",
+		}
+	}
+	grade := &model.Grade{CorrectAnswerIDs: correctAnswerIDs(question)}
+	for _, answer := range question.Answers {
+		if answer.IsCorrect && normalizeText(answer.Text) == text {
+			grade.Correct = true
+			grade.Score = 1
+			break
+		}
+	}
+	return grade, nil
+}
+
+type numericGrader struct{}
+
+func (numericGrader) ValidateQuestion(question *model.Question) error {
+	if question.Tolerance != nil && *question.Tolerance < 0 {
+		return &service_errors.ValidationError{
+			Field:  "tolerance",
+			Reason: "Tolerance must not be negative",
+		}
+	}
+	return nil
+}
+
+func (numericGrader) ValidateAnswer(_ *model.Question, answer *model.Answer) error {
+	if _, err := parseNumber(answer.Text); err != nil {
+		return &service_errors.ValidationError{
+			Field:  "text",
+			Reason: fmt.Sprintf("Numeric answer '%v' must start with a number", answer.Text),
+		}
+	}
+	return nil
+}
+
+func (numericGrader) Grade(question *model.Question, choice *model.Choice) (*model.Grade, error) {
+	value, err := parseNumber(choice.Text)
+	if err != nil {
+		return nil, &service_errors.ValidationError{
+			Field:  "text",
+			Reason: fmt.Sprintf("Answer '%v' is not a number", choice.Text),
+		}
+	}
+	tolerance := 0.0
+	if question.Tolerance != nil {
+		tolerance = *question.Tolerance
+	}
+	grade := &model.Grade{CorrectAnswerIDs: correctAnswerIDs(question)}
+	for _, answer := range question.Answers {
+		if !answer.IsCorrect {
+			continue
+		}
+		expected, err := parseNumber(answer.Text)
+		if err == nil && math.Abs(value-expected) <= tolerance {
+			grade.Correct = true
+			grade.Score = 1
+			break
+		}
+	}
+	return grade, nil
+}
+
+type orderingGrader struct{}
+
+func (orderingGrader) ValidateQuestion(question *model.Question) error {
+	return validateNoTolerance(question)
+}
+
+func (orderingGrader) ValidateAnswer(question *model.Question, answer *model.Answer) error {
+	if answer.Position == nil || *answer.Position <= 0 {
+		return &service_errors.ValidationError{
+			Field:  "position",
+			Reason: "Answer of ordering question must have positive position",
+		}
+	}
+	for _, existing := range question.Answers {
+		if existing.Position != nil && *existing.Position == *answer.Position {
+			return &service_errors.ValidationError{
+				Field:  "position",
+				Reason: fmt.Sprintf("Position %v is already taken by 'Answer' with id=%v", *answer.Position, existing.ID),
+			}
+		}
+	}
+	return nil
+}
+
+// Grade expects all answers in chosen order, with partial credit score is a
+// share of answers placed on their positions.
+func (orderingGrader) Grade(question *model.Question, choice *model.Choice) (*model.Grade, error) {
+	if len(choice.AnswerIDs) != len(question.Answers) {
+		return nil, &service_errors.ValidationError{
+			Field:  "answer_ids",
+			Reason: fmt.Sprintf("All %v answers must be ordered", len(question.Answers)),
+		}
+	}
+	if err := validateBelongs(question, choice.AnswerIDs, "answer_ids"); err != nil {
+		return nil, err
+	}
+	ordered := slices.Clone(question.Answers)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return positionOf(ordered[i]) < positionOf(ordered[j])
+	})
+	grade := &model.Grade{CorrectAnswerIDs: make([]int, 0, len(ordered))}
+	placed := 0
+	for i, answer := range ordered {
+		grade.CorrectAnswerIDs = append(grade.CorrectAnswerIDs, answer.ID)
+		if choice.AnswerIDs[i] == answer.ID {
+			placed++
+		}
+	}
+	grade.Correct = placed == len(ordered)
+	if grade.Correct {
+		grade.Score = 1
+	} else if question.Partial && len(ordered) > 0 {
+		grade.Score = float64(placed) / float64(len(ordered))
+	}
+	return grade, nil
+}
+
+func validateNoTolerance(question *model.Question) error {
+	if question.Tolerance != nil {
+		return &service_errors.ValidationError{
+			Field:  "tolerance",
+			Reason: "Tolerance is supported only by numeric questions",
+		}
+	}
+	return nil
+}
+
+// validateBelongs checks that answers are chosen once and belong to question.
+func validateBelongs(question *model.Question, answerIDs []int, field string) error {
+	seen := make(map[int]bool, len(answerIDs))
+	for _, answerID := range answerIDs {
+		if seen[answerID] {
+			return &service_errors.ValidationError{
+				Field:  field,
+				Reason: fmt.Sprintf("'Answer' with id=%v chosen more than once", answerID),
+			}
+		}
+		seen[answerID] = true
+		belongs := slices.ContainsFunc(question.Answers, func(answer model.Answer) bool {
+			return answer.ID == answerID
+		})
+		if !belongs {
+			return &service_errors.ValidationError{
+				Field:  field,
+				Reason: fmt.Sprintf("'Answer' with id=%v doesn't belong to 'Question' with id=%v", answerID, question.ID),
+			}
+		}
+	}
+	return nil
+}
+
+func correctAnswerIDs(question *model.Question) []int {
+	ids := make([]int, 0)
+	for _, answer := range question.Answers {
+		if answer.IsCorrect {
+			ids = append(ids, answer.ID)
+		}
+	}
+	return ids
+}
+
+func positionOf(answer model.Answer) int {
+	if answer.Position == nil {
+		return math.MaxInt
+	}
+	return *answer.Position
+}
+
+// normalizeText lowercases text, drops punctuation and collapses spaces,
+// so "  Leo  Tolstoy!" matches "leo tolstoy".
+func normalizeText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+var numberPrefix = regexp.MustCompile(`^[+-]?\d+(?:[.,]\d+)?`)
+
+// parseNumber reads number at the beginning of text, so units like "100°C"
+// are allowed.
+func parseNumber(text string) (float64, error) {
+	match := numberPrefix.FindString(strings.TrimSpace(text))
+	if match == "" {
+		return 0, fmt.Errorf("no number in '%v'", text)
+	}
+	return strconv.ParseFloat(strings.Replace(match, ",", ".", 1), 64)
+}
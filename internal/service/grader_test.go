@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+)
+
+func TestGradeQuestion(t *testing.T) {
+	first, second, third := 1, 2, 3
+	tolerance := 0.5
+	single := &model.Question{ID: 1, Answers: []model.Answer{
+		{ID: 1, Text: "Paris", IsCorrect: true},
+		{ID: 2, Text: "Lyon"},
+	}}
+	multiple := func(partial bool) *model.Question {
+		return &model.Question{ID: 2, Type: model.MultipleChoice, Partial: partial, Answers: []model.Answer{
+			{ID: 1, Text: "2", IsCorrect: true},
+			{ID: 2, Text: "3", IsCorrect: true},
+			{ID: 3, Text: "4"},
+		}}
+	}
+	freeText := &model.Question{ID: 3, Type: model.FreeText, Answers: []model.Answer{
+		{ID: 1, Text: "New York", IsCorrect: true},
+	}}
+	numeric := &model.Question{ID: 4, Type: model.Numeric, Tolerance: &tolerance, Answers: []model.Answer{
+		{ID: 1, Text: "100", IsCorrect: true},
+	}}
+	ordering := func(partial bool) *model.Question {
+		return &model.Question{ID: 5, Type: model.Ordering, Partial: partial, Answers: []model.Answer{
+			{ID: 1, Text: "third", Position: &third},
+			{ID: 2, Text: "first", Position: &first},
+			{ID: 3, Text: "second", Position: &second},
+		}}
+	}
+	tests := []struct {
+		name        string
+		question    *model.Question
+		choice      model.Choice
+		wantCorrect bool
+		wantScore   float64
+		wantErr     string
+	}{
+		{name: "single correct", question: single, choice: model.Choice{AnswerID: 1}, wantCorrect: true, wantScore: 1},
+		{name: "single wrong", question: single, choice: model.Choice{AnswerID: 2}},
+		{name: "single foreign answer", question: single, choice: model.Choice{AnswerID: 9}, wantErr: "answer_id"},
+		{name: "multiple all correct", question: multiple(false), choice: model.Choice{AnswerIDs: []int{2, 1}}, wantCorrect: true, wantScore: 1},
+		{name: "multiple missing option", question: multiple(false), choice: model.Choice{AnswerIDs: []int{1}}},
+		{name: "multiple partial missing option", question: multiple(true), choice: model.Choice{AnswerIDs: []int{1}}, wantScore: 0.5},
+		{name: "multiple partial wrong option cancels hit", question: multiple(true), choice: model.Choice{AnswerIDs: []int{1, 3}}},
+		{name: "multiple nothing chosen", question: multiple(false), choice: model.Choice{}, wantErr: "answer_ids"},
+		{name: "multiple chosen twice", question: multiple(false), choice: model.Choice{AnswerIDs: []int{1, 1}}, wantErr: "answer_ids"},
+		{name: "free text ignores case and punctuation", question: freeText, choice: model.Choice{Text: "  new-york! "}, wantCorrect: true, wantScore: 1},
+		{name: "free text wrong", question: freeText, choice: model.Choice{Text: "Boston"}},
+		{name: "free text empty", question: freeText, choice: model.Choice{Text: "?!"}, wantErr: "text"},
+		{name: "numeric exact", question: numeric, choice: model.Choice{Text: "100"}, wantCorrect: true, wantScore: 1},
+		{name: "numeric within tolerance with unit", question: numeric, choice: model.Choice{Text: "99,5°C"}, wantCorrect: true, wantScore: 1},
+		{name: "numeric outside tolerance", question: numeric, choice: model.Choice{Text: "101"}},
+		{name: "numeric not a number", question: numeric, choice: model.Choice{Text: "hot"}, wantErr: "text"},
+		{name: "ordering correct", question: ordering(false), choice: model.Choice{AnswerIDs: []int{2, 3, 1}}, wantCorrect: true, wantScore: 1},
+		{name: "ordering wrong", question: ordering(false), choice: model.Choice{AnswerIDs: []int{2, 1, 3}}},
+		{name: "ordering partial", question: ordering(true), choice: model.Choice{AnswerIDs: []int{2, 1, 3}}, wantScore: 1.0 / 3},
+		{name: "ordering incomplete", question: ordering(false), choice: model.Choice{AnswerIDs: []int{2, 3}}, wantErr: "answer_ids"},
+		{name: "unsupported type", question: &model.Question{Type: "essay"}, choice: model.Choice{Text: "text"}, wantErr: "type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := gradeQuestion(tt.question, &tt.choice)
+			if tt.wantErr != "" {
+				var validationErr *service_errors.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("gradeQuestion() error = %v, want ValidationError", err)
+				}
+				if validationErr.Field != tt.wantErr {
+					t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("gradeQuestion() error = %v", err)
+			}
+			if grade.Correct != tt.wantCorrect || math.Abs(grade.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("gradeQuestion() = %+v, want correct %v with score %v", grade, tt.wantCorrect, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestGraderValidateAnswer(t *testing.T) {
+	taken := 1
+	negative := -1
+	tests := []struct {
+		name     string
+		question *model.Question
+		answer   model.Answer
+		wantErr  bool
+	}{
+		{name: "single any text", question: &model.Question{}, answer: model.Answer{Text: "Paris"}},
+		{name: "free text letters", question: &model.Question{Type: model.FreeText}, answer: model.Answer{Text: "Paris"}},
+		{name: "free text punctuation only", question: &model.Question{Type: model.FreeText}, answer: model.Answer{Text: "..."}, wantErr: true},
+		{name: "numeric number", question: &model.Question{Type: model.Numeric}, answer: model.Answer{Text: "-3.5 m"}},
+		{name: "numeric text", question: &model.Question{Type: model.Numeric}, answer: model.Answer{Text: "three"}, wantErr: true},
+		{name: "ordering without position", question: &model.Question{Type: model.Ordering}, answer: model.Answer{Text: "first"}, wantErr: true},
+		{name: "ordering negative position", question: &model.Question{Type: model.Ordering}, answer: model.Answer{Text: "first", Position: &negative}, wantErr: true},
+		{
+			name:     "ordering position taken",
+			question: &model.Question{Type: model.Ordering, Answers: []model.Answer{{ID: 1, Position: &taken}}},
+			answer:   model.Answer{Text: "first", Position: &taken},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grader, err := GraderFor(tt.question.Type)
+			if err != nil {
+				t.Fatalf("GraderFor() error = %v", err)
+			}
+			err = grader.ValidateAnswer(tt.question, &tt.answer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAnswer() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// MigrationChecker tells whether schema is behind the migrations of binary.
+type MigrationChecker interface {
+	Pending(ctx context.Context) (bool, error)
+}
+
+type HealthLogic interface {
+	// Live reports that process is able to serve requests at all.
+	Live(ctx context.Context) *model.Health
+	// Ready reports whether dependencies are usable, Status is
+	// model.HealthStatusFail when any check failed.
+	Ready(ctx context.Context) *model.Health
+}
+
+type HealthService struct {
+	DB       *gorm.DB
+	Migrator MigrationChecker
+}
+
+func NewHealthService(db *gorm.DB, migrator MigrationChecker) *HealthService {
+	service := new(HealthService)
+	service.DB = db
+	service.Migrator = migrator
+	return service
+}
+
+func (service *HealthService) Live(ctx context.Context) *model.Health {
+	return &model.Health{Status: model.HealthStatusOk}
+}
+
+func (service *HealthService) Ready(ctx context.Context) *model.Health {
+	op := "service.HealthService.Ready"
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+	health := &model.Health{Status: model.HealthStatusOk}
+	check := func(name string, probe func() error) {
+		result := model.HealthCheck{Name: name, Status: model.HealthStatusOk}
+		if err := probe(); err != nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Readiness check failed"),
+				zap.String("check", name),
+				zap.Error(err))
+			result.Status = model.HealthStatusFail
+			result.Error = err.Error()
+			health.Status = model.HealthStatusFail
+		}
+		health.Checks = append(health.Checks, result)
+	}
+	check("postgres", func() error {
+		sqlDB, err := service.DB.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+	check("migrations", func() error {
+		pending, err := service.Migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return fmt.Errorf("schema has pending migrations")
+		}
+		return nil
+	})
+	return health
+}
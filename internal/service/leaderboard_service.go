@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+type LeaderboardLogic interface {
+	Find(ctx context.Context, query *model.LeaderboardQuery) (*model.Leaderboard, error)
+}
+
+type LeaderboardService struct {
+	LeaderboardRepo repository.LeaderboardProvider
+	QuizRepo        repository.QuizProvider
+}
+
+func NewLeaderboardService(
+	leaderboardRepo repository.LeaderboardProvider,
+	quizRepo repository.QuizProvider,
+) *LeaderboardService {
+	srv := new(LeaderboardService)
+	srv.LeaderboardRepo = leaderboardRepo
+	srv.QuizRepo = quizRepo
+	return srv
+}
+
+func (service *LeaderboardService) Find(ctx context.Context, query *model.LeaderboardQuery) (*model.Leaderboard, error) {
+	op := "service.LeaderboardService.Find"
+
+	now := time.Now()
+	switch query.Window {
+	case "", model.WindowAll:
+		query.Window = model.WindowAll
+		query.Since = nil
+	case model.WindowDay:
+		since := now.Add(-24 * time.Hour)
+		query.Since = &since
+	case model.WindowWeek:
+		since := now.Add(-7 * 24 * time.Hour)
+		query.Since = &since
+	default:
+		return nil, &service_errors.ValidationError{
+			Field:  "window",
+			Reason: "Window must be one of 'day', 'week' or 'all'",
+		}
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultLeaderboardLimit
+	}
+	if query.Limit < 0 || query.Limit > maxLeaderboardLimit {
+		return nil, &service_errors.ValidationError{
+			Field:  "limit",
+			Reason: "Limit must be between 1 and 100",
+		}
+	}
+
+	if query.QuizID != nil {
+		quiz, err := service.QuizRepo.GetOne(nil, ctx, *query.QuizID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find quiz of leaderboard"),
+				zap.Int("quiz_id", *query.QuizID))
+			return nil, err
+		}
+		if quiz == nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Quiz of leaderboard not found"),
+				zap.Int("quiz_id", *query.QuizID))
+			return nil, &service_errors.NotFoundError{ID: *query.QuizID, Entity: "Quiz"}
+		}
+	}
+
+	entries, err := service.LeaderboardRepo.GetTop(ctx, query)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to rank leaderboard"))
+		return nil, err
+	}
+	leaderboard := &model.Leaderboard{
+		QuizID:  query.QuizID,
+		Window:  query.Window,
+		Entries: entries,
+	}
+
+	if query.UserID != nil {
+		for i := range entries {
+			if entries[i].UserID == *query.UserID {
+				leaderboard.Me = &entries[i]
+				break
+			}
+		}
+		if leaderboard.Me == nil {
+			leaderboard.Me, err = service.LeaderboardRepo.GetEntry(ctx, query, *query.UserID)
+			if err != nil {
+				common.Log(ctx).Error("Domain error",
+					zap.String("op", op),
+					zap.String("Result", "Error when try to find rank of user"),
+					zap.String("user_id", query.UserID.String()))
+				return nil, err
+			}
+		}
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Leaderboard finded successfully"),
+		zap.String("window", query.Window),
+		zap.Int("count", len(entries)))
+	return leaderboard, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type LogLevelLogic interface {
+	Find(ctx context.Context, actor *model.Actor) (*model.LogLevel, error)
+	// Update changes level of running logger, it is not persisted and is
+	// reset to configured one on restart
+	Update(ctx context.Context, actor *model.Actor, level *model.LogLevel) (*model.LogLevel, error)
+}
+
+type LogLevelService struct {
+	Level zap.AtomicLevel
+}
+
+func NewLogLevelService(level zap.AtomicLevel) *LogLevelService {
+	service := new(LogLevelService)
+	service.Level = level
+	return service
+}
+
+func (service *LogLevelService) Find(ctx context.Context, actor *model.Actor) (*model.LogLevel, error) {
+	if err := authorize(ctx, actor, ManageLogging); err != nil {
+		return nil, err
+	}
+	return &model.LogLevel{Level: service.Level.String()}, nil
+}
+
+func (service *LogLevelService) Update(ctx context.Context, actor *model.Actor, level *model.LogLevel) (*model.LogLevel, error) {
+	op := "service.LogLevelService.Update"
+	if err := authorize(ctx, actor, ManageLogging); err != nil {
+		return nil, err
+	}
+	parsed, err := zapcore.ParseLevel(level.Level)
+	if err != nil {
+		return nil, &service_errors.ValidationError{
+			Field:  "level",
+			Reason: fmt.Sprintf("Level '%v' is unknown, expected debug, info, warn or error", level.Level),
+		}
+	}
+	previous := service.Level.Level()
+	service.Level.SetLevel(parsed)
+	// Logged at warn, so change is visible at any level
+	common.Log(ctx).Warn("Domain warn",
+		zap.String("op", op),
+		zap.String("Result", "Log level changed"),
+		zap.Stringer("from", previous),
+		zap.Stringer("to", parsed),
+		zap.Object("Actor", actor))
+	return &model.LogLevel{Level: parsed.String()}, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// prepareListParams fills defaults of params and validates them.
+func prepareListParams(params *model.ListParams) error {
+	if params.Limit == 0 {
+		params.Limit = defaultPageLimit
+	}
+	if params.Limit < 0 || params.Limit > maxPageLimit {
+		return &service_errors.ValidationError{Field: "limit", Reason: "Limit must be between 1 and 100"}
+	}
+	if params.Offset < 0 {
+		return &service_errors.ValidationError{Field: "offset", Reason: "Offset must not be negative"}
+	}
+	if params.Sort == "" {
+		params.Sort = model.SortIDAsc
+	}
+	switch params.Sort {
+	case model.SortIDAsc, model.SortIDDesc, model.SortCreatedAtAsc, model.SortCreatedAtDesc:
+	default:
+		return &service_errors.ValidationError{
+			Field:  "sort",
+			Reason: "Sort must be one of 'id', '-id', 'created_at' or '-created_at'",
+		}
+	}
+	if params.Cursor != nil {
+		if params.Offset > 0 {
+			return &service_errors.ValidationError{Field: "cursor", Reason: "Cursor and offset can't be used together"}
+		}
+		if params.Cursor.Sort != params.Sort {
+			return &service_errors.ValidationError{Field: "cursor", Reason: "Cursor was issued for another sort"}
+		}
+	}
+	if params.CreatedAfter != nil && params.CreatedBefore != nil && !params.CreatedAfter.Before(*params.CreatedBefore) {
+		return &service_errors.ValidationError{
+			Field:  "created_after",
+			Reason: "'created_after' must be earlier than 'created_before'",
+		}
+	}
+	return nil
+}
+
+// buildPage trims extra row selected by repository and issues cursor to the
+// next page when it exists.
+func buildPage[T any](
+	items []T,
+	total int64,
+	params *model.ListParams,
+	cursorOf func(item T) model.Cursor,
+) (*model.Page[T], error) {
+	page := &model.Page[T]{Items: items, Total: total}
+	if len(items) <= params.Limit {
+		return page, nil
+	}
+	page.Items = items[:params.Limit]
+	cursor := cursorOf(page.Items[params.Limit-1])
+	cursor.Sort = params.Sort
+	nextCursor, err := util.EncodeCursor(&cursor)
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = nextCursor
+	return page, nil
+}
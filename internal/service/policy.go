@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+)
+
+// Actions checked by policy before service methods are performed.
+const (
+	ReadQuestions   = "read questions"
+	ManageQuestions = "manage questions"
+	PurgeQuestions  = "purge questions"
+	ReadAnswers     = "read answers"
+	PostAnswers     = "post answers"
+	ManageAnswers   = "manage answers"
+	PurgeAnswers    = "purge answers"
+	ManageAPIKeys   = "manage api keys"
+	ManageUsers     = "manage users"
+	ManageLogging   = "manage logging"
+	ManageAttempts  = "manage attempts"
+)
+
+var rolePermissions = map[string][]string{
+	model.RolePlayer: {
+		ReadQuestions, ReadAnswers, PostAnswers,
+	},
+	model.RoleEditor: {
+		ReadQuestions, ManageQuestions, ReadAnswers, PostAnswers, ManageAnswers,
+	},
+	model.RoleAdmin: {
+		ReadQuestions, ManageQuestions, PurgeQuestions, ReadAnswers, PostAnswers, ManageAnswers, PurgeAnswers,
+		ManageAPIKeys, ManageUsers, ManageLogging, ManageAttempts,
+	},
+}
+
+var scopePermissions = map[string][]string{
+	model.ScopeQuestionsRead: {
+		ReadQuestions, ReadAnswers,
+	},
+	model.ScopeQuestionsWrite: {
+		ReadQuestions, ManageQuestions,
+	},
+	model.ScopeAnswersWrite: {
+		ReadAnswers, PostAnswers,
+	},
+	model.ScopeAdmin: {
+		ReadQuestions, ManageQuestions, PurgeQuestions, ReadAnswers, PostAnswers, ManageAnswers, PurgeAnswers,
+		ManageAPIKeys, ManageUsers, ManageLogging, ManageAttempts,
+	},
+}
+
+// ownerPermissions are granted to any role on resources owned by actor.
+var ownerPermissions = []string{ManageAnswers, ManageAttempts}
+
+// authorize returns ForbiddenError when role of actor is not allowed to
+// perform action.
+func authorize(ctx context.Context, actor *model.Actor, action string) error {
+	return authorizeOwned(ctx, actor, action, uuid.Nil)
+}
+
+// authorizeOwned is like authorize, but also allows owner permissions when
+// actor is owner of affected resource.
+func authorizeOwned(ctx context.Context, actor *model.Actor, action string, owner uuid.UUID) error {
+	if actor == nil {
+		return &service_errors.ForbiddenError{Action: action}
+	}
+	if allowed(actor, action) {
+		return nil
+	}
+	if owner != uuid.Nil && owner == actor.UserID && slices.Contains(ownerPermissions, action) {
+		return nil
+	}
+	common.Log(ctx).Warn("Domain warn",
+		zap.String("op", "service.authorize"),
+		zap.String("Result", "Actor is not allowed to perform action"),
+		zap.Object("Actor", actor),
+		zap.String("action", action))
+	return &service_errors.ForbiddenError{Role: actor.Role, Action: action}
+}
+
+// allowed reports whether actor may perform action, unlike authorize it
+// doesn't log denial, so it suits checks which only change result.
+func allowed(actor *model.Actor, action string) bool {
+	return actor != nil && slices.Contains(permissionsOf(actor), action)
+}
+
+// hideAnswerKey hides answer key of answer unless actor manages questions.
+func hideAnswerKey(actor *model.Actor, answer *model.Answer) {
+	if !allowed(actor, ManageQuestions) {
+		answer.HideKey()
+	}
+}
+
+// hideQuestionKey hides answer key of question unless actor manages
+// questions.
+func hideQuestionKey(actor *model.Actor, question *model.Question) {
+	if !allowed(actor, ManageQuestions) {
+		question.HideKey()
+	}
+}
+
+// hideAnswersKey is hideAnswerKey for every answer of answers.
+func hideAnswersKey(actor *model.Actor, answers []model.Answer) {
+	for i := range answers {
+		hideAnswerKey(actor, &answers[i])
+	}
+}
+
+// permissionsOf returns permissions of role of actor or, when actor is
+// authenticated with API key, permissions granted by scopes of the key.
+func permissionsOf(actor *model.Actor) []string {
+	if actor.Role != model.RoleAPIKey {
+		return rolePermissions[actor.Role]
+	}
+	var permissions []string
+	for _, scope := range actor.Scopes {
+		permissions = append(permissions, scopePermissions[scope]...)
+	}
+	return permissions
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mbilarusdev/quiz/internal/model"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+)
+
+func TestAuthorizeOwned(t *testing.T) {
+	owner := uuid.New()
+	player := &model.Actor{UserID: owner, Role: model.RolePlayer}
+	stranger := &model.Actor{UserID: uuid.New(), Role: model.RolePlayer}
+	tests := []struct {
+		name    string
+		actor   *model.Actor
+		action  string
+		owner   uuid.UUID
+		allowed bool
+	}{
+		{name: "anonymous", actor: nil, action: ReadQuestions},
+		{name: "player reads questions", actor: player, action: ReadQuestions, allowed: true},
+		{name: "player manages questions", actor: player, action: ManageQuestions},
+		{name: "owner manages own answer", actor: player, action: ManageAnswers, owner: owner, allowed: true},
+		{name: "owner manages own attempt", actor: player, action: ManageAttempts, owner: owner, allowed: true},
+		{name: "stranger manages attempt", actor: stranger, action: ManageAttempts, owner: owner},
+		{name: "nil owner isn't owned by actor", actor: &model.Actor{Role: model.RolePlayer}, action: ManageAttempts, owner: uuid.Nil},
+		{name: "ownership doesn't grant purge", actor: player, action: PurgeAnswers, owner: owner},
+		{name: "editor manages any answer", actor: &model.Actor{UserID: uuid.New(), Role: model.RoleEditor}, action: ManageAnswers, owner: owner, allowed: true},
+		{name: "editor manages other's attempt", actor: &model.Actor{UserID: uuid.New(), Role: model.RoleEditor}, action: ManageAttempts, owner: owner},
+		{name: "admin manages any attempt", actor: &model.Actor{UserID: uuid.New(), Role: model.RoleAdmin}, action: ManageAttempts, owner: owner, allowed: true},
+		{name: "api key scope grants action", actor: &model.Actor{Role: model.RoleAPIKey, Scopes: []string{model.ScopeQuestionsWrite}}, action: ManageQuestions, allowed: true},
+		{name: "api key manages answer of its owner", actor: &model.Actor{UserID: owner, Role: model.RoleAPIKey, Scopes: []string{model.ScopeAnswersWrite}}, action: ManageAnswers, owner: owner, allowed: true},
+		{name: "api key manages other's answer", actor: &model.Actor{UserID: uuid.New(), Role: model.RoleAPIKey, Scopes: []string{model.ScopeAnswersWrite}}, action: ManageAnswers, owner: owner},
+		{name: "api key scope lacks action", actor: &model.Actor{Role: model.RoleAPIKey, Scopes: []string{model.ScopeQuestionsRead}}, action: PostAnswers},
+		{name: "unknown role", actor: &model.Actor{UserID: owner, Role: "root"}, action: ReadQuestions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizeOwned(context.Background(), tt.actor, tt.action, tt.owner)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("authorizeOwned() error = %v, want nil", err)
+				}
+				return
+			}
+			var forbiddenErr *service_errors.ForbiddenError
+			if !errors.As(err, &forbiddenErr) {
+				t.Fatalf("authorizeOwned() error = %v, want ForbiddenError", err)
+			}
+			if forbiddenErr.Action != tt.action {
+				t.Errorf("ForbiddenError.Action = %q, want %q", forbiddenErr.Action, tt.action)
+			}
+		})
+	}
+}
+
+func TestPermissionsOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   *model.Actor
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "player",
+			actor:   &model.Actor{Role: model.RolePlayer},
+			want:    []string{ReadQuestions, ReadAnswers, PostAnswers},
+			notWant: []string{ManageQuestions, ManageAnswers, ManageAttempts},
+		},
+		{
+			name:    "editor",
+			actor:   &model.Actor{Role: model.RoleEditor},
+			want:    []string{ManageQuestions, ManageAnswers},
+			notWant: []string{PurgeQuestions, ManageUsers, ManageAttempts},
+		},
+		{
+			name:  "admin",
+			actor: &model.Actor{Role: model.RoleAdmin},
+			want:  []string{PurgeQuestions, PurgeAnswers, ManageAPIKeys, ManageUsers, ManageLogging, ManageAttempts},
+		},
+		{
+			name:    "unknown role",
+			actor:   &model.Actor{Role: "root"},
+			notWant: []string{ReadQuestions},
+		},
+		{
+			name:    "api key with several scopes",
+			actor:   &model.Actor{Role: model.RoleAPIKey, Scopes: []string{model.ScopeQuestionsRead, model.ScopeAnswersWrite}},
+			want:    []string{ReadQuestions, ReadAnswers, PostAnswers},
+			notWant: []string{ManageQuestions, ManageAnswers, ManageAttempts},
+		},
+		{
+			name:    "api key with unknown scope",
+			actor:   &model.Actor{Role: model.RoleAPIKey, Scopes: []string{"questions:delete"}},
+			notWant: []string{ReadQuestions, PurgeQuestions},
+		},
+		{
+			name:    "api key without scopes ignores role permissions",
+			actor:   &model.Actor{Role: model.RoleAPIKey},
+			notWant: []string{ReadQuestions},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions := permissionsOf(tt.actor)
+			for _, action := range tt.want {
+				if !slices.Contains(permissions, action) {
+					t.Errorf("permissionsOf() = %v, missing %q", permissions, action)
+				}
+			}
+			for _, action := range tt.notWant {
+				if slices.Contains(permissions, action) {
+					t.Errorf("permissionsOf() = %v, unexpectedly has %q", permissions, action)
+				}
+			}
+		})
+	}
+}
@@ -2,112 +2,591 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
 
 	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/metrics"
 	"github.com/mbilarusdev/quiz/internal/model"
 	"github.com/mbilarusdev/quiz/internal/repository"
 	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"github.com/mbilarusdev/quiz/internal/util"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type QuestionLogic interface {
-	Create(question *model.Question) (*model.Question, error)
-	FindOneDetailed(questionID int) (*model.Question, error)
-	FindAll() ([]model.Question, error)
-	Delete(questionID int) (bool, error)
+	Create(ctx context.Context, actor *model.Actor, question *model.Question) (*model.Question, error)
+	// Update, Patch and Delete apply only to question with given version,
+	// zero version matches any
+	Update(ctx context.Context, actor *model.Actor, questionID int, version int, question *model.Question) (*model.Question, error)
+	Patch(ctx context.Context, actor *model.Actor, questionID int, version int, patch []byte) (*model.Question, error)
+	FindOneDetailed(ctx context.Context, actor *model.Actor, questionID int) (*model.Question, error)
+	FindAll(ctx context.Context, actor *model.Actor, params *model.ListParams) (*model.Page[model.Question], error)
+	Delete(ctx context.Context, actor *model.Actor, questionID int, version int) (bool, error)
+	FindTrash(ctx context.Context, actor *model.Actor, params *model.ListParams) (*model.Page[model.Question], error)
+	// Restore restores soft deleted question, withAnswers restores answers
+	// which were deleted together with question
+	Restore(ctx context.Context, actor *model.Actor, questionID int, withAnswers bool) (*model.Question, error)
+	Purge(ctx context.Context, actor *model.Actor, questionID int, version int) (bool, error)
+	Grade(ctx context.Context, actor *model.Actor, questionID int, submission *model.Submission) (*model.SubmissionResult, error)
 }
 
 type QuestionService struct {
 	QuestionRepo repository.QuestionProvider
 	AnswerRepo   repository.AnswerProvider
+	DB           *gorm.DB
 }
 
 func NewQuestionService(
 	questionRepo repository.QuestionProvider,
 	answerRepo repository.AnswerProvider,
+	db *gorm.DB,
 ) *QuestionService {
 	service := new(QuestionService)
 	service.QuestionRepo = questionRepo
 	service.AnswerRepo = answerRepo
+	service.DB = db
 	return service
 }
 
-func (service *QuestionService) Create(question *model.Question) (*model.Question, error) {
+func (service *QuestionService) Create(ctx context.Context, actor *model.Actor, question *model.Question) (*model.Question, error) {
 	op := "service.QuestionService.Create"
-	question, err := service.QuestionRepo.Insert(context.Background(), question)
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	question.Version = 0
+	if err := validateQuestion(question); err != nil {
+		return nil, err
+	}
+	// Options created together with question are authored by actor, like
+	// answers posted by AddAnswer
+	for i := range question.Answers {
+		question.Answers[i].UserID = actor.UserID
+	}
+	question, err := service.QuestionRepo.Insert(ctx, question)
 	if err != nil {
-		common.L.Error("Domain error",
+		common.Log(ctx).Error("Domain error",
 			zap.String("op", op),
 			zap.String("Result", "Error when try to create question"))
 		return nil, err
 	}
-	common.L.Info("Domain info",
+	metrics.QuestionsCreated.Inc()
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
 		zap.String("Result", "Question created with success"),
 		zap.Int("id", question.ID))
 	return question, nil
 }
 
-func (service *QuestionService) FindOneDetailed(questionID int) (*model.Question, error) {
+func (service *QuestionService) Update(
+	ctx context.Context,
+	actor *model.Actor,
+	questionID int,
+	version int,
+	question *model.Question,
+) (*model.Question, error) {
+	op := "service.QuestionService.Update"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	return service.update(ctx, op, questionID, version, func(_ *model.Question) (*model.Question, error) {
+		return question, nil
+	})
+}
+
+func (service *QuestionService) Patch(ctx context.Context, actor *model.Actor, questionID int, version int, patch []byte) (*model.Question, error) {
+	op := "service.QuestionService.Patch"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	return service.update(ctx, op, questionID, version, func(existing *model.Question) (*model.Question, error) {
+		existing.Answers = nil
+		jsonQuestion, err := json.Marshal(existing)
+		if err != nil {
+			return nil, err
+		}
+		patched, err := util.MergePatch(jsonQuestion, patch)
+		if err != nil {
+			return nil, &service_errors.ValidationError{Field: "body", Reason: "Merge patch is not a valid JSON"}
+		}
+		question := &model.Question{}
+		if err := json.Unmarshal(patched, question); err != nil {
+			return nil, &service_errors.ValidationError{Field: "body", Reason: "Patched 'Question' is not valid"}
+		}
+		return question, nil
+	})
+}
+
+// update replaces editable fields of question with ones built by change from
+// existing question, question type and existing answers are validated again.
+func (service *QuestionService) update(
+	ctx context.Context,
+	op string,
+	questionID int,
+	version int,
+	change func(existing *model.Question) (*model.Question, error),
+) (*model.Question, error) {
+	var updated *model.Question
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := service.QuestionRepo.GetOne(tx, ctx, questionID, true)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find question to update"),
+				zap.Int("id", questionID))
+			return err
+		}
+		if existing == nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Question to update not found!"),
+				zap.Int("id", questionID))
+			return &service_errors.NotFoundError{ID: questionID, Entity: "Question"}
+		}
+		if version != 0 && existing.Version != version {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Question to update has another version"),
+				zap.Int("id", questionID),
+				zap.Int("version", version),
+				zap.Int("current_version", existing.Version))
+			return &service_errors.ConflictError{ID: questionID, Entity: "Question", Version: existing.Version}
+		}
+		answers := existing.Answers
+		currentVersion := existing.Version
+
+		question, err := change(existing)
+		if err != nil {
+			return err
+		}
+		if question.ID != 0 && question.ID != questionID {
+			return &service_errors.ValidationError{Field: "id", Reason: "Field 'id' can't be changed"}
+		}
+		question.ID = questionID
+		question.Version = currentVersion
+		if err := validateText(question.Text); err != nil {
+			return err
+		}
+		if err := validateQuestion(question); err != nil {
+			return err
+		}
+		grader, err := GraderFor(question.Type)
+		if err != nil {
+			return err
+		}
+		for i := range answers {
+			others := &model.Question{ID: questionID, Type: question.Type, Answers: without(answers, i)}
+			if err := grader.ValidateAnswer(others, &answers[i]); err != nil {
+				common.Log(ctx).Warn("Domain warn",
+					zap.String("op", op),
+					zap.String("Result", "Existing answer is not valid for new question type"),
+					zap.Int("id", questionID),
+					zap.Int("answer_id", answers[i].ID))
+				return err
+			}
+		}
+
+		updatedRow, err := service.QuestionRepo.Update(tx, ctx, question)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to update question"),
+				zap.Int("id", questionID))
+			return err
+		}
+		if !updatedRow {
+			return service.conflictOf(tx, ctx, questionID)
+		}
+		updated, err = service.QuestionRepo.GetOne(tx, ctx, questionID, false)
+		return err
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Question updated with success"),
+		zap.Int("id", questionID))
+	return updated, nil
+}
+
+func (service *QuestionService) FindOneDetailed(ctx context.Context, actor *model.Actor, questionID int) (*model.Question, error) {
 	op := "service.QuestionService.FindOneDetailed"
-	ctx := context.Background()
+	if err := authorize(ctx, actor, ReadQuestions); err != nil {
+		return nil, err
+	}
 	question, err := service.QuestionRepo.GetOne(nil, ctx, questionID, true)
 	if err != nil {
-		common.L.Error("Domain error",
+		common.Log(ctx).Error("Domain error",
 			zap.String("op", op),
 			zap.String("Result", "Error when try to find question"),
 			zap.Int("id", questionID))
 		return nil, err
 	}
 	if question == nil {
-		common.L.Warn("Domain warn",
+		common.Log(ctx).Warn("Domain warn",
 			zap.String("op", op),
 			zap.String("Result", "Question not found!"),
 			zap.Int("id", questionID))
 		return question, &service_errors.NotFoundError{ID: questionID}
 	}
 
-	common.L.Info("Domain info",
+	hideQuestionKey(actor, question)
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
 		zap.String("Result", "Question finded with success"),
 		zap.Int("id", questionID))
 	return question, nil
 }
 
-func (service *QuestionService) FindAll() ([]model.Question, error) {
-	op := "service.QuestionService.FindOneDetailed"
-	questions, err := service.QuestionRepo.GetAll(context.Background())
+func (service *QuestionService) FindAll(ctx context.Context, actor *model.Actor, params *model.ListParams) (*model.Page[model.Question], error) {
+	op := "service.QuestionService.FindAll"
+	if err := authorize(ctx, actor, ReadQuestions); err != nil {
+		return nil, err
+	}
+	if err := prepareListParams(params); err != nil {
+		return nil, err
+	}
+	questions, total, err := service.QuestionRepo.GetAll(ctx, params)
 	if err != nil {
-		common.L.Error("Domain error",
+		common.Log(ctx).Error("Domain error",
 			zap.String("op", op),
-			zap.String("Result", "Error when try to find all of questions"))
-		return make([]model.Question, 0), err
+			zap.String("Result", "Error when try to find page of questions"))
+		return nil, err
+	}
+	page, err := buildPage(questions, total, params, questionCursor)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to build page of questions"))
+		return nil, err
 	}
-	common.L.Info("Domain info",
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
-		zap.String("Result", "All of questions finded successfully"))
-	return questions, nil
+		zap.String("Result", "Page of questions finded successfully"),
+		zap.Int("count", len(page.Items)),
+		zap.Int64("total", page.Total))
+	return page, nil
 }
 
-func (service *QuestionService) Delete(questionID int) (bool, error) {
+func (service *QuestionService) Delete(ctx context.Context, actor *model.Actor, questionID int, version int) (bool, error) {
 	op := "service.QuestionService.Delete"
-	deleted, err := service.QuestionRepo.Delete(context.Background(), questionID)
-	if err != nil {
-		common.L.Error("Domain error",
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return false, err
+	}
+	var deleted bool
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		deleted, err = service.QuestionRepo.Delete(tx, ctx, questionID, version)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to delete question"),
+				zap.Int("id", questionID))
+			return err
+		}
+		if !deleted && version != 0 {
+			err = service.conflictOf(tx, ctx, questionID)
+			if _, ok := err.(*service_errors.NotFoundError); ok {
+				return nil
+			}
+			return err
+		}
+		if !deleted {
+			return nil
+		}
+		question, err := service.QuestionRepo.GetDeleted(tx, ctx, questionID)
+		if err != nil {
+			return err
+		}
+		count, err := service.AnswerRepo.DeleteByQuestion(tx, ctx, questionID, question.DeletedAt.Time)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to delete answers of question"),
+				zap.Int("id", questionID))
+			return err
+		}
+		common.Log(ctx).Info("Domain info",
 			zap.String("op", op),
-			zap.String("Result", "Error when try to delete question"),
-			zap.Int("id", questionID))
+			zap.String("Result", "Answers deleted together with question"),
+			zap.Int("id", questionID),
+			zap.Int64("answers", count))
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
 		return false, err
 	}
 	if !deleted {
-		common.L.Warn("Domain warn",
+		common.Log(ctx).Warn("Domain warn",
 			zap.String("op", op),
 			zap.String("Result", "Question to delete not found!"),
 			zap.Int("id", questionID))
+		return false, nil
 	}
-	common.L.Info("Domain info",
+	common.Log(ctx).Info("Domain info",
 		zap.String("op", op),
 		zap.String("Result", "Question deleted successfully!"),
 		zap.Int("id", questionID))
 	return deleted, nil
 }
+
+func (service *QuestionService) FindTrash(ctx context.Context, actor *model.Actor, params *model.ListParams) (*model.Page[model.Question], error) {
+	op := "service.QuestionService.FindTrash"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	if err := prepareListParams(params); err != nil {
+		return nil, err
+	}
+	questions, total, err := service.QuestionRepo.GetTrash(ctx, params)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find page of deleted questions"))
+		return nil, err
+	}
+	page, err := buildPage(questions, total, params, questionCursor)
+	if err != nil {
+		return nil, err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Page of deleted questions finded successfully"),
+		zap.Int("count", len(page.Items)),
+		zap.Int64("total", page.Total))
+	return page, nil
+}
+
+func (service *QuestionService) Restore(ctx context.Context, actor *model.Actor, questionID int, withAnswers bool) (*model.Question, error) {
+	op := "service.QuestionService.Restore"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	var restored *model.Question
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted, err := service.QuestionRepo.GetDeleted(tx, ctx, questionID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find deleted question"),
+				zap.Int("id", questionID))
+			return err
+		}
+		if deleted == nil {
+			active, err := service.QuestionRepo.GetOne(tx, ctx, questionID, false)
+			if err != nil {
+				return err
+			}
+			if active != nil {
+				return &service_errors.StateError{ID: questionID, State: "active", Action: "restored"}
+			}
+			return &service_errors.NotFoundError{ID: questionID, Entity: "Question"}
+		}
+		if _, err := service.QuestionRepo.Restore(tx, ctx, questionID); err != nil {
+			return err
+		}
+		if withAnswers {
+			_, err := service.AnswerRepo.RestoreDeletedWith(tx, ctx, questionID, deleted.DeletedAt.Time)
+			if err != nil {
+				return err
+			}
+		}
+		restored, err = service.QuestionRepo.GetOne(tx, ctx, questionID, true)
+		return err
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Question restored successfully!"),
+		zap.Int("id", questionID),
+		zap.Int("answers", len(restored.Answers)))
+	return restored, nil
+}
+
+func (service *QuestionService) Purge(ctx context.Context, actor *model.Actor, questionID int, version int) (bool, error) {
+	op := "service.QuestionService.Purge"
+	if err := authorize(ctx, actor, PurgeQuestions); err != nil {
+		return false, err
+	}
+	var purged bool
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		purged, err = service.QuestionRepo.Purge(tx, ctx, questionID, version)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to purge question"),
+				zap.Int("id", questionID))
+			return err
+		}
+		if purged || version == 0 {
+			return nil
+		}
+		current, err := service.QuestionRepo.GetOne(tx, ctx, questionID, false)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			current, err = service.QuestionRepo.GetDeleted(tx, ctx, questionID)
+			if err != nil {
+				return err
+			}
+		}
+		if current != nil {
+			return &service_errors.ConflictError{ID: questionID, Entity: "Question", Version: current.Version}
+		}
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return false, err
+	}
+	if !purged {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Question to purge not found!"),
+			zap.Int("id", questionID))
+		return false, nil
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Question purged successfully!"),
+		zap.Int("id", questionID))
+	return true, nil
+}
+
+// conflictOf explains why question with expected version was not changed,
+// it returns ConflictError when question exists and NotFoundError otherwise.
+func (service *QuestionService) conflictOf(tx *gorm.DB, ctx context.Context, questionID int) error {
+	current, err := service.QuestionRepo.GetOne(tx, ctx, questionID, false)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return &service_errors.NotFoundError{ID: questionID, Entity: "Question"}
+	}
+	return &service_errors.ConflictError{ID: questionID, Entity: "Question", Version: current.Version}
+}
+
+func (service *QuestionService) Grade(
+	ctx context.Context,
+	actor *model.Actor,
+	questionID int,
+	submission *model.Submission,
+) (*model.SubmissionResult, error) {
+	op := "service.QuestionService.Grade"
+	if err := authorize(ctx, actor, ReadQuestions); err != nil {
+		return nil, err
+	}
+	question, err := service.QuestionRepo.GetOne(nil, ctx, questionID, true)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find question to grade"),
+			zap.Int("id", questionID))
+		return nil, err
+	}
+	if question == nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Question to grade not found!"),
+			zap.Int("id", questionID))
+		return nil, &service_errors.NotFoundError{ID: questionID}
+	}
+
+	grade, err := gradeQuestion(question, &submission.Choice)
+	if err != nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Submission can't be graded"),
+			zap.Int("id", questionID),
+			zap.Error(err))
+		return nil, err
+	}
+	result := &model.SubmissionResult{
+		QuestionID: questionID,
+		UserID:     submission.UserID,
+		Choice:     submission.Choice,
+		Grade:      *grade,
+	}
+	if !allowed(actor, ManageQuestions) {
+		result.CorrectAnswerIDs = nil
+	}
+
+	metrics.ObserveGrade(metrics.SourceSubmission, questionType(question), result.Correct, result.Score)
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Submission graded successfully"),
+		zap.Int("id", questionID),
+		zap.String("type", question.Type),
+		zap.Bool("correct", result.Correct),
+		zap.Float64("score", result.Score))
+	return result, nil
+}
+
+func validateTimeLimit(timeLimit *int) error {
+	if timeLimit != nil && *timeLimit <= 0 {
+		return &service_errors.ValidationError{
+			Field:  "time_limit_seconds",
+			Reason: "Time limit must be a positive number of seconds",
+		}
+	}
+	return nil
+}
+
+// validateQuestion checks question settings and fills default type.
+func validateQuestion(question *model.Question) error {
+	if err := validateTimeLimit(question.TimeLimit); err != nil {
+		return err
+	}
+	grader, err := GraderFor(question.Type)
+	if err != nil {
+		return err
+	}
+	if err := grader.ValidateQuestion(question); err != nil {
+		return err
+	}
+	if question.Type == "" {
+		question.Type = model.SingleChoice
+	}
+	return nil
+}
+
+func validateText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return &service_errors.ValidationError{Field: "text", Reason: "Field 'text' must not be empty"}
+	}
+	return nil
+}
+
+func without(answers []model.Answer, i int) []model.Answer {
+	others := make([]model.Answer, 0, len(answers))
+	others = append(others, answers[:i]...)
+	return append(others, answers[i+1:]...)
+}
+
+func questionCursor(question model.Question) model.Cursor {
+	return model.Cursor{ID: question.ID, CreatedAt: question.CreatedAt}
+}
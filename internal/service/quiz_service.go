@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type QuizLogic interface {
+	Create(ctx context.Context, actor *model.Actor, quiz *model.Quiz) (*model.Quiz, error)
+	FindOne(ctx context.Context, quizID int) (*model.Quiz, error)
+	FindAll(ctx context.Context) ([]model.Quiz, error)
+	Update(ctx context.Context, actor *model.Actor, quizID int, quiz *model.Quiz) (*model.Quiz, error)
+	Delete(ctx context.Context, actor *model.Actor, quizID int) (bool, error)
+	FindQuestions(ctx context.Context, quizID int) ([]model.Question, error)
+	SetQuestions(ctx context.Context, actor *model.Actor, quizID int, questionIDs []int) ([]model.Question, error)
+}
+
+type QuizService struct {
+	QuizRepo     repository.QuizProvider
+	QuestionRepo repository.QuestionProvider
+	DB           *gorm.DB
+}
+
+func NewQuizService(
+	quizRepo repository.QuizProvider,
+	questionRepo repository.QuestionProvider,
+	db *gorm.DB,
+) *QuizService {
+	srv := new(QuizService)
+	srv.QuizRepo = quizRepo
+	srv.QuestionRepo = questionRepo
+	srv.DB = db
+	return srv
+}
+
+func (service *QuizService) Create(ctx context.Context, actor *model.Actor, quiz *model.Quiz) (*model.Quiz, error) {
+	op := "service.QuizService.Create"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	var newQuiz *model.Quiz
+	if err := validateTimeLimit(quiz.TimeLimit); err != nil {
+		return nil, err
+	}
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		newQuiz, err = service.QuizRepo.Insert(tx, ctx, quiz)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to create quiz"))
+			return err
+		}
+		newQuiz.Questions, err = service.linkQuestions(tx, ctx, newQuiz.ID, quiz.QuestionIDs)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to link questions to created quiz"),
+				zap.Int("id", newQuiz.ID))
+			return err
+		}
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Quiz created with success"),
+		zap.Int("id", newQuiz.ID))
+	return newQuiz, nil
+}
+
+func (service *QuizService) FindOne(ctx context.Context, quizID int) (*model.Quiz, error) {
+	op := "service.QuizService.FindOne"
+	quiz, err := service.QuizRepo.GetOne(nil, ctx, quizID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find quiz"),
+			zap.Int("id", quizID))
+		return nil, err
+	}
+	if quiz == nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Quiz not found!"),
+			zap.Int("id", quizID))
+		return nil, &service_errors.NotFoundError{ID: quizID, Entity: "Quiz"}
+	}
+	quiz.Questions, err = service.QuizRepo.GetQuestions(nil, ctx, quizID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find questions of quiz"),
+			zap.Int("id", quizID))
+		return nil, err
+	}
+	quiz.QuestionIDs = questionIDsOf(quiz.Questions)
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Quiz finded with success"),
+		zap.Int("id", quizID))
+	return quiz, nil
+}
+
+func (service *QuizService) FindAll(ctx context.Context) ([]model.Quiz, error) {
+	op := "service.QuizService.FindAll"
+	quizzes, err := service.QuizRepo.GetAll(ctx)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find all of quizzes"))
+		return make([]model.Quiz, 0), err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "All of quizzes finded successfully"))
+	return quizzes, nil
+}
+
+func (service *QuizService) Update(ctx context.Context, actor *model.Actor, quizID int, quiz *model.Quiz) (*model.Quiz, error) {
+	op := "service.QuizService.Update"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	if err := validateTimeLimit(quiz.TimeLimit); err != nil {
+		return nil, err
+	}
+	quiz.ID = quizID
+	updated, err := service.QuizRepo.Update(ctx, quiz)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to update quiz"),
+			zap.Int("id", quizID))
+		return nil, err
+	}
+	if !updated {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Quiz to update not found!"),
+			zap.Int("id", quizID))
+		return nil, &service_errors.NotFoundError{ID: quizID, Entity: "Quiz"}
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Quiz updated successfully!"),
+		zap.Int("id", quizID))
+	return service.FindOne(ctx, quizID)
+}
+
+func (service *QuizService) Delete(ctx context.Context, actor *model.Actor, quizID int) (bool, error) {
+	op := "service.QuizService.Delete"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return false, err
+	}
+	deleted, err := service.QuizRepo.Delete(ctx, quizID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to delete quiz"),
+			zap.Int("id", quizID))
+		return false, err
+	}
+	if !deleted {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Quiz to delete not found!"),
+			zap.Int("id", quizID))
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Quiz deleted successfully!"),
+		zap.Int("id", quizID))
+	return deleted, nil
+}
+
+func (service *QuizService) FindQuestions(ctx context.Context, quizID int) ([]model.Question, error) {
+	op := "service.QuizService.FindQuestions"
+	quiz, err := service.QuizRepo.GetOne(nil, ctx, quizID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find quiz"),
+			zap.Int("id", quizID))
+		return nil, err
+	}
+	if quiz == nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Quiz not found!"),
+			zap.Int("id", quizID))
+		return nil, &service_errors.NotFoundError{ID: quizID, Entity: "Quiz"}
+	}
+	questions, err := service.QuizRepo.GetQuestions(nil, ctx, quizID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find questions of quiz"),
+			zap.Int("id", quizID))
+		return nil, err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Questions of quiz finded successfully"),
+		zap.Int("id", quizID))
+	return questions, nil
+}
+
+func (service *QuizService) SetQuestions(ctx context.Context, actor *model.Actor, quizID int, questionIDs []int) ([]model.Question, error) {
+	op := "service.QuizService.SetQuestions"
+	if err := authorize(ctx, actor, ManageQuestions); err != nil {
+		return nil, err
+	}
+	var questions []model.Question
+
+	err := service.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		quiz, err := service.QuizRepo.GetOne(tx, ctx, quizID)
+		if err != nil {
+			common.Log(ctx).Error("Domain error",
+				zap.String("op", op),
+				zap.String("Result", "Error when try to find quiz which questions needed to set"),
+				zap.Int("id", quizID))
+			return err
+		}
+		if quiz == nil {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Quiz which questions needed to set not found"),
+				zap.Int("id", quizID))
+			return &service_errors.NotFoundError{ID: quizID, Entity: "Quiz"}
+		}
+		questions, err = service.linkQuestions(tx, ctx, quizID, questionIDs)
+		return err
+	}, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Questions of quiz set successfully"),
+		zap.Int("id", quizID),
+		zap.Ints("question_ids", questionIDs))
+	return questions, nil
+}
+
+// linkQuestions checks that every question exists and replaces quiz questions
+// keeping the order of questionIDs.
+func (service *QuizService) linkQuestions(
+	tx *gorm.DB,
+	ctx context.Context,
+	quizID int,
+	questionIDs []int,
+) ([]model.Question, error) {
+	op := "service.QuizService.linkQuestions"
+	seen := make(map[int]bool, len(questionIDs))
+	for _, questionID := range questionIDs {
+		if seen[questionID] {
+			return nil, &service_errors.ValidationError{
+				Field:  "question_ids",
+				Reason: fmt.Sprintf("'Question' with id=%v listed more than once", questionID),
+			}
+		}
+		seen[questionID] = true
+	}
+
+	existing, err := service.QuestionRepo.GetMany(tx, ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]model.Question, len(existing))
+	for _, question := range existing {
+		byID[question.ID] = question
+	}
+	questions := make([]model.Question, 0, len(questionIDs))
+	for _, questionID := range questionIDs {
+		question, ok := byID[questionID]
+		if !ok {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Question to link with quiz not found"),
+				zap.Int("quiz_id", quizID),
+				zap.Int("question_id", questionID))
+			return nil, &service_errors.NotFoundError{ID: questionID, Entity: "Question"}
+		}
+		questions = append(questions, question)
+	}
+
+	if err := service.QuizRepo.ReplaceQuestions(tx, ctx, quizID, questionIDs); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func questionIDsOf(questions []model.Question) []int {
+	ids := make([]int, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+	}
+	return ids
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/common"
+	"github.com/mbilarusdev/quiz/internal/model"
+	"github.com/mbilarusdev/quiz/internal/repository"
+	service_errors "github.com/mbilarusdev/quiz/internal/service/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt ignores bytes after 72th
+	maxPasswordLength = 72
+)
+
+// dummyPasswordHash is compared on login with unknown email, so response time
+// doesn't reveal whether account exists.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// TokenIssuer issues bearer tokens of actors.
+type TokenIssuer interface {
+	Issue(actor *model.Actor) (string, time.Time, error)
+}
+
+type UserLogic interface {
+	Register(ctx context.Context, registration *model.Registration) (*model.User, error)
+	// Create registers account with any role on behalf of admin
+	Create(ctx context.Context, actor *model.Actor, registration *model.Registration, role string) (*model.User, error)
+	Login(ctx context.Context, credentials *model.Credentials) (*model.Session, error)
+	FindMe(ctx context.Context, actor *model.Actor) (*model.User, error)
+	// UpdateMe changes profile fields of actor, which are display name only
+	UpdateMe(ctx context.Context, actor *model.Actor, user *model.User) (*model.User, error)
+	ChangePassword(ctx context.Context, actor *model.Actor, change *model.PasswordChange) error
+}
+
+type UserService struct {
+	UserRepo repository.UserProvider
+	Issuer   TokenIssuer
+}
+
+func NewUserService(userRepo repository.UserProvider, issuer TokenIssuer) *UserService {
+	service := new(UserService)
+	service.UserRepo = userRepo
+	service.Issuer = issuer
+	return service
+}
+
+func (service *UserService) Register(ctx context.Context, registration *model.Registration) (*model.User, error) {
+	return service.create(ctx, "service.UserService.Register", registration, model.RolePlayer)
+}
+
+func (service *UserService) Create(
+	ctx context.Context,
+	actor *model.Actor,
+	registration *model.Registration,
+	role string,
+) (*model.User, error) {
+	if err := authorize(ctx, actor, ManageUsers); err != nil {
+		return nil, err
+	}
+	if _, ok := rolePermissions[role]; !ok {
+		return nil, &service_errors.ValidationError{
+			Field:  "role",
+			Reason: fmt.Sprintf("Role '%v' is unknown, expected player, editor or admin", role),
+		}
+	}
+	return service.create(ctx, "service.UserService.Create", registration, role)
+}
+
+func (service *UserService) create(ctx context.Context, op string, registration *model.Registration, role string) (*model.User, error) {
+	email, err := normalizeEmail(registration.Email)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePassword("password", registration.Password); err != nil {
+		return nil, err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(registration.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &model.User{
+		Email:        email,
+		DisplayName:  strings.TrimSpace(registration.DisplayName),
+		PasswordHash: string(passwordHash),
+		Role:         role,
+	}
+	user, err = service.UserRepo.Insert(ctx, user)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to register user"))
+		return nil, err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "User registered with success"),
+		zap.String("id", user.ID.String()),
+		zap.String("role", user.Role))
+	return user, nil
+}
+
+func (service *UserService) Login(ctx context.Context, credentials *model.Credentials) (*model.Session, error) {
+	op := "service.UserService.Login"
+	email := strings.ToLower(strings.TrimSpace(credentials.Email))
+	user, err := service.UserRepo.GetByEmail(ctx, email)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find user to login"))
+		return nil, err
+	}
+	if user == nil || user.PasswordHash == "" {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(credentials.Password))
+		return nil, &service_errors.CredentialsError{}
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(credentials.Password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			common.Log(ctx).Warn("Domain warn",
+				zap.String("op", op),
+				zap.String("Result", "Wrong password on login"),
+				zap.String("id", user.ID.String()))
+			return nil, &service_errors.CredentialsError{}
+		}
+		return nil, err
+	}
+	token, expiresAt, err := service.Issuer.Issue(&model.Actor{UserID: user.ID, Role: user.Role})
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to issue token"),
+			zap.String("id", user.ID.String()))
+		return nil, err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "User logged in with success"),
+		zap.String("id", user.ID.String()))
+	return &model.Session{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
+
+func (service *UserService) FindMe(ctx context.Context, actor *model.Actor) (*model.User, error) {
+	op := "service.UserService.FindMe"
+	if actor == nil {
+		return nil, &service_errors.ForbiddenError{Action: "read profile"}
+	}
+	user, err := service.UserRepo.GetOne(ctx, actor.UserID)
+	if err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to find user"),
+			zap.String("id", actor.UserID.String()))
+		return nil, err
+	}
+	if user == nil {
+		return nil, &service_errors.NotFoundError{Entity: "User"}
+	}
+	return user, nil
+}
+
+func (service *UserService) UpdateMe(ctx context.Context, actor *model.Actor, user *model.User) (*model.User, error) {
+	op := "service.UserService.UpdateMe"
+	existing, err := service.FindMe(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	existing.DisplayName = strings.TrimSpace(user.DisplayName)
+	if _, err := service.UserRepo.Update(ctx, existing); err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to update user"),
+			zap.String("id", existing.ID.String()))
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (service *UserService) ChangePassword(ctx context.Context, actor *model.Actor, change *model.PasswordChange) error {
+	op := "service.UserService.ChangePassword"
+	user, err := service.FindMe(ctx, actor)
+	if err != nil {
+		return err
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(change.CurrentPassword))
+	if user.PasswordHash == "" || err != nil {
+		common.Log(ctx).Warn("Domain warn",
+			zap.String("op", op),
+			zap.String("Result", "Wrong current password"),
+			zap.String("id", user.ID.String()))
+		return &service_errors.CredentialsError{}
+	}
+	if err := validatePassword("new_password", change.NewPassword); err != nil {
+		return err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(change.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(passwordHash)
+	if _, err := service.UserRepo.Update(ctx, user); err != nil {
+		common.Log(ctx).Error("Domain error",
+			zap.String("op", op),
+			zap.String("Result", "Error when try to change password"),
+			zap.String("id", user.ID.String()))
+		return err
+	}
+	common.Log(ctx).Info("Domain info",
+		zap.String("op", op),
+		zap.String("Result", "Password changed with success"),
+		zap.String("id", user.ID.String()))
+	return nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", &service_errors.ValidationError{Field: "email", Reason: "Field 'email' must be a valid email address"}
+	}
+	return email, nil
+}
+
+func validatePassword(field string, password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return &service_errors.ValidationError{
+			Field:  field,
+			Reason: "Password must be from 8 to 72 bytes long",
+		}
+	}
+	return nil
+}
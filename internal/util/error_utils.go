@@ -1,7 +1,31 @@
 package util
 
-import "strings"
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes of postgres errors.
+const (
+	foreignKeyViolation = "23503"
+	queryCanceled       = "57014"
+)
 
 func CheckDublicateErr(err error) bool {
 	return strings.Contains(err.Error(), "duplicate key value violates unique constraint")
 }
+
+// CheckForeignKeyErr reports whether err violates foreign key constraint.
+func CheckForeignKeyErr(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation && pgErr.ConstraintName == constraint
+}
+
+// CheckQueryCanceledErr reports whether postgres cancelled query, e.g. when
+// deadline of query passed.
+func CheckQueryCanceledErr(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == queryCanceled
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestCheckForeignKeyErr(t *testing.T) {
+	violation := &pgconn.PgError{Code: foreignKeyViolation, ConstraintName: "fk_answers_user_id"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "violation of constraint", err: violation, want: true},
+		{name: "wrapped violation", err: fmt.Errorf("insert answer: %w", violation), want: true},
+		{name: "violation of other constraint", err: &pgconn.PgError{Code: foreignKeyViolation, ConstraintName: "fk_other"}},
+		{name: "other postgres error", err: &pgconn.PgError{Code: "23505", ConstraintName: "fk_answers_user_id"}},
+		{name: "not postgres error", err: errors.New(`violates foreign key constraint "fk_answers_user_id"`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckForeignKeyErr(tt.err, "fk_answers_user_id"); got != tt.want {
+				t.Errorf("CheckForeignKeyErr(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckQueryCanceledErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "cancelled query", err: &pgconn.PgError{Code: queryCanceled}, want: true},
+		{name: "wrapped cancelled query", err: fmt.Errorf("find question: %w", &pgconn.PgError{Code: queryCanceled}), want: true},
+		{name: "other postgres error", err: &pgconn.PgError{Code: foreignKeyViolation}},
+		{name: "not postgres error", err: errors.New("canceling statement due to user request")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckQueryCanceledErr(tt.err); got != tt.want {
+				t.Errorf("CheckQueryCanceledErr(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
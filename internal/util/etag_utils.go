@@ -0,0 +1,42 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ETag formats version of entity as strong entity tag.
+func ETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ParseIfMatch returns version from If-Match header, "*" matches any
+// version and is returned as zero.
+func ParseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, nil
+	}
+	unquoted, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, fmt.Errorf("If-Match must be a single strong entity tag or '*', got %q", header)
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("If-Match entity tag %q is not a version", header)
+	}
+	return version, nil
+}
+
+// IfNoneMatch reports whether If-None-Match header matches etag, entity tags
+// are compared weakly as required for GET requests.
+func IfNoneMatch(header string, etag string) bool {
+	for tag := range strings.SplitSeq(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package util
+
+import "testing"
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int
+		wantErr bool
+	}{
+		{name: "strong tag", header: `"3"`, want: 3},
+		{name: "surrounding spaces", header: ` "12" `, want: 12},
+		{name: "any version", header: "*", want: 0},
+		{name: "round trip of ETag", header: ETag(42), want: 42},
+		{name: "empty", header: "", wantErr: true},
+		{name: "unquoted", header: "3", wantErr: true},
+		{name: "weak tag", header: `W/"3"`, wantErr: true},
+		{name: "several tags", header: `"3", "4"`, wantErr: true},
+		{name: "not a number", header: `"abc"`, wantErr: true},
+		{name: "zero version", header: `"0"`, wantErr: true},
+		{name: "negative version", header: `"-1"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIfMatch(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIfMatch(%q) error = %v, want error %v", tt.header, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseIfMatch(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package util
+
+import (
+	"encoding/json"
+	"mime"
+)
+
+const MergePatchContentType = "application/merge-patch+json"
+
+// MergePatch applies JSON Merge Patch (RFC 7396) to original document.
+func MergePatch(original []byte, patch []byte) ([]byte, error) {
+	var originalValue any
+	if err := json.Unmarshal(original, &originalValue); err != nil {
+		return nil, err
+	}
+	var patchValue any
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(originalValue, patchValue))
+}
+
+func mergeValue(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergeValue(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+// IsMergePatch reports whether content type of request body can be applied
+// as merge patch, plain JSON is accepted too.
+func IsMergePatch(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == MergePatchContentType || mediaType == "application/json"
+}
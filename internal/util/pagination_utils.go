@@ -0,0 +1,72 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+)
+
+func EncodeCursor(cursor *model.Cursor) (string, error) {
+	jsonCursor, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(jsonCursor), nil
+}
+
+func DecodeCursor(raw string) (*model.Cursor, error) {
+	jsonCursor, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	cursor := &model.Cursor{}
+	if err := json.Unmarshal(jsonCursor, cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+// ParseListParams reads limit, offset, cursor, sort, q, created_after and
+// created_before query parameters, returned error names broken parameter.
+func ParseListParams(values url.Values) (*model.ListParams, error) {
+	params := &model.ListParams{
+		Sort:  values.Get("sort"),
+		Query: values.Get("q"),
+	}
+	var err error
+	if raw := values.Get("limit"); raw != "" {
+		if params.Limit, err = strconv.Atoi(raw); err != nil {
+			return nil, fmt.Errorf("query parameter 'limit': %w", err)
+		}
+	}
+	if raw := values.Get("offset"); raw != "" {
+		if params.Offset, err = strconv.Atoi(raw); err != nil {
+			return nil, fmt.Errorf("query parameter 'offset': %w", err)
+		}
+	}
+	if raw := values.Get("cursor"); raw != "" {
+		if params.Cursor, err = DecodeCursor(raw); err != nil {
+			return nil, fmt.Errorf("query parameter 'cursor': %w", err)
+		}
+	}
+	if raw := values.Get("created_after"); raw != "" {
+		createdAfter, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("query parameter 'created_after': %w", err)
+		}
+		params.CreatedAfter = &createdAfter
+	}
+	if raw := values.Get("created_before"); raw != "" {
+		createdBefore, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("query parameter 'created_before': %w", err)
+		}
+		params.CreatedBefore = &createdBefore
+	}
+	return params, nil
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/mbilarusdev/quiz/internal/model"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor model.Cursor
+	}{
+		{name: "by id", cursor: model.Cursor{Sort: "id", ID: 17}},
+		{name: "by creation time", cursor: model.Cursor{Sort: "-created_at", ID: 5, CreatedAt: time.Date(2025, 11, 27, 16, 22, 8, 123456000, time.UTC)}},
+		{name: "empty", cursor: model.Cursor{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := EncodeCursor(&tt.cursor)
+			if err != nil {
+				t.Fatalf("EncodeCursor() error = %v", err)
+			}
+			got, err := DecodeCursor(raw)
+			if err != nil {
+				t.Fatalf("DecodeCursor(%q) error = %v", raw, err)
+			}
+			if got.Sort != tt.cursor.Sort || got.ID != tt.cursor.ID || !got.CreatedAt.Equal(tt.cursor.CreatedAt) {
+				t.Errorf("DecodeCursor(EncodeCursor(%+v)) = %+v", tt.cursor, got)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "not base64", raw: "%%%"},
+		{name: "padded base64", raw: base64.URLEncoding.EncodeToString([]byte(`{"id":1}`))},
+		{name: "not json", raw: base64.RawURLEncoding.EncodeToString([]byte("id=1"))},
+		{name: "wrong field type", raw: base64.RawURLEncoding.EncodeToString([]byte(`{"id":"1"}`))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := DecodeCursor(tt.raw); err == nil {
+				t.Errorf("DecodeCursor(%q) = %+v, want error", tt.raw, cursor)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ func SendError(
 	params model.SendError,
 ) {
 	responseError := model.ResError{Error: params.ErrorMsg, StatusCode: params.StatusCode}
-	common.L.Error(
+	common.Log(params.R.Context()).Error(
 		fmt.Sprintf("Handler '%v' returns error", params.HandlerName),
 		zap.String("Path", params.R.URL.Path),
 		zap.String("Method", params.R.Method),
@@ -24,7 +24,7 @@ func SendError(
 	)
 	jsonErr, err := json.Marshal(responseError)
 	if err != nil {
-		common.L.Fatal("json.Marshal failed in util.SendError")
+		common.Log(params.R.Context()).Fatal("json.Marshal failed in util.SendError")
 	}
 	params.W.WriteHeader(params.StatusCode)
 	params.W.Write(jsonErr)
@@ -33,7 +33,7 @@ func SendError(
 func SendSuccess(
 	params model.SendSuccess,
 ) {
-	common.L.Info(
+	common.Log(params.R.Context()).Info(
 		fmt.Sprintf("Handler '%v' returns success", params.HandlerName),
 		zap.String("Path", params.R.URL.Path),
 		zap.String("Method", params.R.Method),
@@ -49,7 +49,7 @@ func SendFatal(
 ) {
 	statusCode := http.StatusInternalServerError
 	responseError := model.ResError{Error: "Panic occured on server side", StatusCode: statusCode}
-	common.L.Error(
+	common.Log(params.R.Context()).Error(
 		fmt.Sprintf("Handler '%v' occured with panic", params.HandlerName),
 		zap.String("Path", params.R.URL.Path),
 		zap.String("Method", params.R.Method),
@@ -58,7 +58,7 @@ func SendFatal(
 	)
 	jsonErr, err := json.Marshal(responseError)
 	if err != nil {
-		common.L.Error("json.Marshal failed in util.SendFatal")
+		common.Log(params.R.Context()).Error("json.Marshal failed in util.SendFatal")
 	}
 	params.W.WriteHeader(statusCode)
 	params.W.Write(jsonErr)
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE answers ADD COLUMN is_correct BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE answers SET is_correct = TRUE
+WHERE (question_id, text) IN (
+    (1, 'Paris'),
+    (2, 'Leo Tolstoy'),
+    (3, 'Mars'),
+    (4, 'Seven'),
+    (5, 'Pacific Ocean'),
+    (6, 'Leonardo da Vinci'),
+    (7, '1945'),
+    (8, 'Au'),
+    (9, 'Mount Everest'),
+    (10, '100°C')
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE answers DROP COLUMN is_correct;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE quizzes (
+    id SERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_quizzes_deleted_at ON quizzes (deleted_at);
+CREATE TABLE quiz_questions (
+    quiz_id INT NOT NULL,
+    question_id INT NOT NULL,
+    position INT NOT NULL,
+    PRIMARY KEY (quiz_id, question_id),
+    UNIQUE (quiz_id, position),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES questions(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX idx_quiz_questions_question_id ON quiz_questions (question_id);
+INSERT INTO quizzes (title, description) VALUES
+('General knowledge', 'Ten questions about geography, history, art and science');
+INSERT INTO quiz_questions (quiz_id, question_id, position)
+SELECT 1, id, id FROM questions WHERE id BETWEEN 1 AND 10;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS quiz_questions;
+DROP TABLE IF EXISTS quizzes;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE attempts (
+    id SERIAL PRIMARY KEY,
+    quiz_id INT NOT NULL,
+    user_id UUID NOT NULL,
+    status TEXT NOT NULL DEFAULT 'started',
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX idx_attempts_quiz_id ON attempts (quiz_id);
+CREATE INDEX idx_attempts_user_id ON attempts (user_id);
+CREATE TABLE attempt_responses (
+    id SERIAL PRIMARY KEY,
+    attempt_id INT NOT NULL,
+    question_id INT NOT NULL,
+    answer_id INT,
+    is_correct BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (attempt_id, question_id),
+    FOREIGN KEY (attempt_id) REFERENCES attempts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES questions(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    -- Purged answer must not erase responses of finished attempts
+    FOREIGN KEY (answer_id) REFERENCES answers(id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attempt_responses;
+DROP TABLE IF EXISTS attempts;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN time_limit_seconds INT;
+ALTER TABLE questions ADD COLUMN time_limit_seconds INT;
+ALTER TABLE attempts ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE attempts ADD COLUMN current_question_id INT;
+ALTER TABLE attempts ADD COLUMN question_started_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX idx_attempts_active_expires_at ON attempts (expires_at)
+WHERE status IN ('started', 'in_progress');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_attempts_active_expires_at;
+ALTER TABLE attempts DROP COLUMN question_started_at;
+ALTER TABLE attempts DROP COLUMN current_question_id;
+ALTER TABLE attempts DROP COLUMN expires_at;
+ALTER TABLE questions DROP COLUMN time_limit_seconds;
+ALTER TABLE quizzes DROP COLUMN time_limit_seconds;
+-- +goose StatementEnd
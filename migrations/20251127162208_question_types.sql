@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN type TEXT NOT NULL DEFAULT 'single_choice';
+ALTER TABLE questions ADD COLUMN tolerance DOUBLE PRECISION;
+ALTER TABLE questions ADD COLUMN partial_credit BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE answers ADD COLUMN position INT;
+ALTER TABLE attempt_responses ADD COLUMN answer_ids JSONB;
+ALTER TABLE attempt_responses ADD COLUMN text TEXT NOT NULL DEFAULT '';
+ALTER TABLE attempt_responses ADD COLUMN score DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE attempt_responses SET score = 1 WHERE is_correct;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE attempt_responses DROP COLUMN score;
+ALTER TABLE attempt_responses DROP COLUMN text;
+ALTER TABLE attempt_responses DROP COLUMN answer_ids;
+ALTER TABLE answers DROP COLUMN position;
+ALTER TABLE questions DROP COLUMN partial_credit;
+ALTER TABLE questions DROP COLUMN tolerance;
+ALTER TABLE questions DROP COLUMN type;
+-- +goose StatementEnd
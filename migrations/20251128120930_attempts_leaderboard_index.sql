@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_attempts_leaderboard ON attempts (quiz_id, finished_at)
+WHERE status IN ('finished', 'expired');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_attempts_leaderboard;
+-- +goose StatementEnd
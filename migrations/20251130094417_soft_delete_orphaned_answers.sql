@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE answers SET deleted_at = questions.deleted_at
+FROM questions
+WHERE answers.question_id = questions.id
+  AND questions.deleted_at IS NOT NULL
+  AND answers.deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 1;
+-- +goose StatementEnd
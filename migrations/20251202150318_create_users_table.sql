@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    email TEXT NOT NULL,
+    display_name TEXT NOT NULL DEFAULT '',
+    password_hash TEXT NOT NULL DEFAULT '',
+    role TEXT NOT NULL DEFAULT 'player',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+-- Answers were posted with random user ids, keep them as accounts without
+-- password, so they can't log in but stay referenced.
+INSERT INTO users (id, email)
+SELECT DISTINCT user_id, 'legacy-' || user_id || '@quiz.local'
+FROM answers;
+ALTER TABLE answers
+    ADD CONSTRAINT fk_answers_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE answers DROP CONSTRAINT IF EXISTS fk_answers_user_id;
+DROP TABLE IF EXISTS users;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Nil user owns answers created by operator tools acting without account.
+INSERT INTO users (id, email)
+VALUES ('00000000-0000-0000-0000-000000000000', 'system@quiz.local')
+ON CONFLICT (id) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM users
+WHERE id = '00000000-0000-0000-0000-000000000000'
+    AND NOT EXISTS (
+        SELECT 1 FROM answers WHERE user_id = '00000000-0000-0000-0000-000000000000'
+    );
+-- +goose StatementEnd
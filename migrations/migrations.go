@@ -0,0 +1,7 @@
+// Package migrations embeds SQL migrations of schema into binary.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS